@@ -218,3 +218,22 @@ func BenchmarkEmitString(b *testing.B) {
 		_ = stringKeyVal.Value.Emit()
 	}
 }
+
+var (
+	keyBytes = []byte("http.method")
+	outKV    attribute.KeyValue
+)
+
+func BenchmarkStringFromBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		outKV = attribute.String(string(keyBytes), "GET")
+	}
+}
+
+func BenchmarkStringFromInternKeyBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		outKV = attribute.InternKeyBytes(keyBytes).String("GET")
+	}
+}
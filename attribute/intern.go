@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute // import "go.opentelemetry.io/otel/attribute"
+
+import "sync"
+
+// maxInternedKeys is the maximum number of keys held by the intern cache.
+// Keys are not interned once it is reached so the cache cannot grow
+// unbounded with high cardinality keys.
+const maxInternedKeys = 1024
+
+var interned = struct {
+	sync.RWMutex
+	keys map[string]Key
+}{keys: make(map[string]Key)}
+
+// InternKey returns the Key for name. Keys returned for the same name share
+// their backing storage.
+//
+// Interning is only useful for keys built at runtime, e.g. decoded from a
+// request. Keys from string constants already share their storage and do not
+// need to be interned.
+func InternKey(name string) Key {
+	interned.RLock()
+	k, ok := interned.keys[name]
+	interned.RUnlock()
+	if ok {
+		return k
+	}
+	return internKey(name)
+}
+
+// InternKeyBytes returns the Key for the name held by b. It does not
+// allocate if the Key has already been interned.
+func InternKeyBytes(b []byte) Key {
+	interned.RLock()
+	// Indexing with string(b) does not allocate.
+	k, ok := interned.keys[string(b)]
+	interned.RUnlock()
+	if ok {
+		return k
+	}
+	return internKey(string(b))
+}
+
+func internKey(name string) Key {
+	interned.Lock()
+	defer interned.Unlock()
+	if k, ok := interned.keys[name]; ok {
+		return k
+	}
+	k := Key(name)
+	if len(interned.keys) < maxInternedKeys {
+		interned.keys[name] = k
+	}
+	return k
+}
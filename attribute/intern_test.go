@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// data returns a pointer to the backing storage of k.
+func data(k attribute.Key) uintptr {
+	s := string(k)
+	return *(*uintptr)(unsafe.Pointer(&s))
+}
+
+func TestInternKey(t *testing.T) {
+	name := fmt.Sprintf("intern.%s", "key")
+	k := attribute.InternKey(name)
+	assert.Equal(t, attribute.Key("intern.key"), k)
+
+	again := attribute.InternKey(fmt.Sprintf("intern.%s", "key"))
+	assert.Equal(t, k, again)
+	assert.Equal(t, data(k), data(again), "interned keys do not share storage")
+
+	fromBytes := attribute.InternKeyBytes([]byte("intern.key"))
+	assert.Equal(t, data(k), data(fromBytes), "interned keys do not share storage")
+}
+
+func TestInternKeyConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	keys := make([]attribute.Key, 10)
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i] = attribute.InternKeyBytes([]byte("intern.concurrent"))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, k := range keys {
+		assert.Equal(t, attribute.Key("intern.concurrent"), k)
+		assert.Equal(t, data(keys[0]), data(k))
+	}
+}
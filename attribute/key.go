@@ -14,6 +14,8 @@
 
 package attribute // import "go.opentelemetry.io/otel/attribute"
 
+import "time"
+
 // Key represents the key part in key-value pairs. It's a string. The
 // allowed character set in the key depends on the use of the key.
 type Key string
@@ -83,6 +85,33 @@ func (k Key) Int(v int) KeyValue {
 	}
 }
 
+// Duration creates a KeyValue instance with a DURATION Value.
+//
+// If creating both key and a duration value at the same time, then
+// instead of calling Key(name).Duration(value) consider using a
+// convenience function provided by the api/key package -
+// key.Duration(name, value).
+func (k Key) Duration(v time.Duration) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: DurationValue(v),
+	}
+}
+
+// Bytes creates a KeyValue instance with a BYTES Value holding a copy of
+// v.
+//
+// If creating both key and a bytes value at the same time, then
+// instead of calling Key(name).Bytes(value) consider using a
+// convenience function provided by the api/key package -
+// key.Bytes(name, value).
+func (k Key) Bytes(v []byte) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: BytesValue(v),
+	}
+}
+
 // Defined returns true for non-empty keys.
 func (k Key) Defined() bool {
 	return len(k) != 0
@@ -17,6 +17,7 @@ package attribute_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -84,6 +85,16 @@ func TestEmit(t *testing.T) {
 			v:    attribute.Float64Value(42.1),
 			want: "42.1",
 		},
+		{
+			name: `test Key.Emit() can emit a string representing self.DURATION`,
+			v:    attribute.DurationValue(1500 * time.Millisecond),
+			want: "1.5s",
+		},
+		{
+			name: `test Key.Emit() can emit a string representing self.BYTES`,
+			v:    attribute.BytesValue([]byte("foo")),
+			want: "Zm9v",
+		},
 		{
 			name: `test Key.Emit() can emit a string representing self.STRING`,
 			v:    attribute.StringValue("foo"),
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"time"
 )
 
 // KeyValue holds a key and value pair.
@@ -55,6 +57,29 @@ func String(k, v string) KeyValue {
 	return Key(k).String(v)
 }
 
+// StringMap flattens m into key-value pairs with string values, keyed by
+// prefix, a dot, and the key of m, e.g. "http.header.accept" for the
+// "accept" key and the "http.header" prefix. A trailing dot of prefix is not
+// repeated, as for a Namespace, and the empty prefix does not prefix the
+// keys. The pairs are sorted by key so their order is deterministic.
+func StringMap(prefix string, m map[string]string) []KeyValue {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ns := Namespace(prefix)
+	kvs := make([]KeyValue, len(keys))
+	for i, k := range keys {
+		kvs[i] = ns.String(k, m[k])
+	}
+	return kvs
+}
+
 // Stringer creates a new key-value pair with a passed name and a string
 // value generated by the passed Stringer interface.
 func Stringer(k string, v fmt.Stringer) KeyValue {
@@ -68,6 +93,18 @@ func Int(k string, v int) KeyValue {
 	return Key(k).Int(v)
 }
 
+// Duration creates a new key-value pair with a passed name and a
+// time.Duration value.
+func Duration(k string, v time.Duration) KeyValue {
+	return Key(k).Duration(v)
+}
+
+// Bytes creates a new key-value pair with a passed name and a byte slice
+// value.
+func Bytes(k string, v []byte) KeyValue {
+	return Key(k).Bytes(v)
+}
+
 // Array creates a new key-value pair with a passed name and a array.
 // Only arrays of primitive type are supported.
 func Array(k string, v interface{}) KeyValue {
@@ -17,6 +17,7 @@ package attribute_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -53,6 +54,22 @@ func TestKeyValueConstructors(t *testing.T) {
 				Value: attribute.Float64Value(123.5),
 			},
 		},
+		{
+			name:   "Duration",
+			actual: attribute.Duration("k1", time.Second),
+			expected: attribute.KeyValue{
+				Key:   "k1",
+				Value: attribute.DurationValue(time.Second),
+			},
+		},
+		{
+			name:   "Bytes",
+			actual: attribute.Bytes("k1", []byte{0x01, 0xff}),
+			expected: attribute.KeyValue{
+				Key:   "k1",
+				Value: attribute.BytesValue([]byte{0x01, 0xff}),
+			},
+		},
 		{
 			name:   "String",
 			actual: attribute.String("k1", "123.5"),
@@ -161,6 +178,14 @@ func TestAny(t *testing.T) {
 	}
 }
 
+func TestAnyBytesNotInferred(t *testing.T) {
+	// Any keeps treating a []byte as an array, Bytes creates a BYTES value.
+	kv := attribute.Any("k", []byte("foo"))
+	if got := kv.Value.Type(); got != attribute.INVALID {
+		t.Errorf("wrong value type, got %#v, expected %#v", got, attribute.INVALID)
+	}
+}
+
 func TestKeyValueValid(t *testing.T) {
 	tests := []struct {
 		desc  string
@@ -219,3 +244,27 @@ func TestKeyValueValid(t *testing.T) {
 		}
 	}
 }
+
+func TestStringMap(t *testing.T) {
+	m := map[string]string{"user-agent": "curl", "accept": "*/*", "host": "example.com"}
+	want := []attribute.KeyValue{
+		attribute.String("http.header.accept", "*/*"),
+		attribute.String("http.header.host", "example.com"),
+		attribute.String("http.header.user-agent", "curl"),
+	}
+	for _, prefix := range []string{"http.header", "http.header."} {
+		if diff := cmp.Diff(attribute.StringMap(prefix, m), want, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+			t.Errorf("StringMap(%q): -got +want %s", prefix, diff)
+		}
+	}
+
+	got := attribute.StringMap("", map[string]string{"b": "2", "a": "1"})
+	want = []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Errorf("StringMap without prefix: -got +want %s", diff)
+	}
+
+	if got := attribute.StringMap("prefix", nil); got != nil {
+		t.Errorf("StringMap of a nil map: got %v, want nil", got)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute // import "go.opentelemetry.io/otel/attribute"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Namespace is a prefix of attribute keys, like "myapp". Its methods create
+// KeyValues with their key in the namespace: the namespace, a dot, and the
+// passed name. This enforces a naming convention for the keys without
+// concatenating them manually, e.g.
+//
+//	ns := attribute.Namespace("myapp")
+//	ns.String("user", "alice") // attribute.String("myapp.user", "alice")
+//
+// A trailing dot of the namespace is not repeated. The empty Namespace does
+// not prefix keys.
+type Namespace string
+
+// Key returns the Key name in ns.
+func (ns Namespace) Key(name string) Key {
+	switch {
+	case ns == "":
+		return Key(name)
+	case strings.HasSuffix(string(ns), "."):
+		return Key(string(ns) + name)
+	}
+	return Key(string(ns) + "." + name)
+}
+
+// Namespace returns the Namespace name nested in ns.
+func (ns Namespace) Namespace(name string) Namespace {
+	return Namespace(ns.Key(name))
+}
+
+// Bool creates a KeyValue with a BOOL Value and the key name in ns.
+func (ns Namespace) Bool(name string, v bool) KeyValue {
+	return ns.Key(name).Bool(v)
+}
+
+// Int64 creates a KeyValue with an INT64 Value and the key name in ns.
+func (ns Namespace) Int64(name string, v int64) KeyValue {
+	return ns.Key(name).Int64(v)
+}
+
+// Float64 creates a KeyValue with a FLOAT64 Value and the key name in ns.
+func (ns Namespace) Float64(name string, v float64) KeyValue {
+	return ns.Key(name).Float64(v)
+}
+
+// String creates a KeyValue with a STRING Value and the key name in ns.
+func (ns Namespace) String(name, v string) KeyValue {
+	return ns.Key(name).String(v)
+}
+
+// Stringer creates a KeyValue with a STRING Value, the result of the
+// String method of v, and the key name in ns.
+func (ns Namespace) Stringer(name string, v fmt.Stringer) KeyValue {
+	return Stringer(string(ns.Key(name)), v)
+}
+
+// Int creates a KeyValue with an INT64 Value and the key name in ns.
+func (ns Namespace) Int(name string, v int) KeyValue {
+	return ns.Key(name).Int(v)
+}
+
+// Duration creates a KeyValue with a DURATION Value and the key name in ns.
+func (ns Namespace) Duration(name string, v time.Duration) KeyValue {
+	return ns.Key(name).Duration(v)
+}
+
+// Bytes creates a KeyValue with a BYTES Value and the key name in ns.
+func (ns Namespace) Bytes(name string, v []byte) KeyValue {
+	return ns.Key(name).Bytes(v)
+}
+
+// Array creates a KeyValue with an ARRAY Value and the key name in ns.
+func (ns Namespace) Array(name string, v interface{}) KeyValue {
+	return ns.Key(name).Array(v)
+}
+
+// Any creates a KeyValue with the key name in ns as Any does.
+func (ns Namespace) Any(name string, v interface{}) KeyValue {
+	return Any(string(ns.Key(name)), v)
+}
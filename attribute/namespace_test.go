@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNamespace(t *testing.T) {
+	ns := attribute.Namespace("myapp")
+	ip := net.IPv4(127, 0, 0, 1)
+	tests := []struct {
+		got, want attribute.KeyValue
+	}{
+		{ns.Bool("b", true), attribute.Bool("myapp.b", true)},
+		{ns.Int64("i64", 42), attribute.Int64("myapp.i64", 42)},
+		{ns.Float64("f", 4.2), attribute.Float64("myapp.f", 4.2)},
+		{ns.String("s", "v"), attribute.String("myapp.s", "v")},
+		{ns.Stringer("ip", ip), attribute.Stringer("myapp.ip", ip)},
+		{ns.Int("i", 42), attribute.Int("myapp.i", 42)},
+		{ns.Duration("d", time.Second), attribute.Duration("myapp.d", time.Second)},
+		{ns.Array("a", []string{"x"}), attribute.Array("myapp.a", []string{"x"})},
+		{ns.Any("any", 42), attribute.Any("myapp.any", 42)},
+		{ns.Namespace("http").String("method", "GET"), attribute.String("myapp.http.method", "GET")},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.got)
+	}
+}
+
+func TestNamespaceKey(t *testing.T) {
+	assert.Equal(t, attribute.Key("myapp.user"), attribute.Namespace("myapp").Key("user"))
+	assert.Equal(t, attribute.Key("myapp.user"), attribute.Namespace("myapp.").Key("user"))
+	assert.Equal(t, attribute.Key("user"), attribute.Namespace("").Key("user"))
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute // import "go.opentelemetry.io/otel/attribute"
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/internal"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// FromStruct returns the exported fields of the struct v, or of the struct v
+// points to, as attributes keyed by prefix, a dot, and the field name, or
+// only the field name if prefix is empty. The fields of embedded structs are
+// returned as if they were fields of v.
+//
+// Fields of boolean, integer, floating point, string, time.Duration types,
+// or implementing fmt.Stringer are returned as attributes of the matching
+// type. Fields of array or slice types are returned as ARRAY attributes if
+// ArrayValue supports their element type. The fields of a struct field are
+// returned keyed by the key of the struct field, a dot, and their names, as
+// long as they are not structs themselves: only one level of nested structs
+// is flattened. Nil pointer and interface fields are skipped, the others are
+// replaced by the value they hold. Fields of other types are skipped and
+// reported to the global ErrorHandler.
+//
+// If v is not a struct, or a pointer to one, it is reported to the global
+// ErrorHandler and nil is returned.
+func FromStruct(prefix string, v interface{}) []KeyValue {
+	rv, ok := indirect(reflect.ValueOf(v))
+	if !ok || rv.Kind() != reflect.Struct {
+		internal.Handle(fmt.Errorf("attribute: FromStruct of non-struct %T", v))
+		return nil
+	}
+	return appendStruct(nil, prefix, rv, 0)
+}
+
+// maxStructDepth is the number of levels of nested structs FromStruct
+// flattens.
+const maxStructDepth = 1
+
+// appendStruct appends the attributes of the fields of the struct rv at
+// depth nested structs to kvs.
+func appendStruct(kvs []KeyValue, prefix string, rv reflect.Value, depth int) []KeyValue {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		fv, ok := indirect(rv.Field(i))
+		if !ok {
+			continue
+		}
+		if f.Anonymous && fv.Kind() == reflect.Struct {
+			kvs = appendStruct(kvs, prefix, fv, depth)
+			continue
+		}
+
+		key := f.Name
+		if prefix != "" {
+			key = prefix + "." + f.Name
+		}
+		if kv, ok := fieldKeyValue(key, fv); ok {
+			kvs = append(kvs, kv)
+			continue
+		}
+		if fv.Kind() == reflect.Struct && depth < maxStructDepth {
+			kvs = appendStruct(kvs, key, fv, depth+1)
+			continue
+		}
+		internal.Handle(fmt.Errorf("attribute: FromStruct skipped field %s of unsupported type %s", key, fv.Type()))
+	}
+	return kvs
+}
+
+// fieldKeyValue returns the attribute of the field value v with key k, and
+// if the type of v is supported.
+func fieldKeyValue(k string, v reflect.Value) (KeyValue, bool) {
+	if v.Type() == durationType {
+		return Duration(k, time.Duration(v.Int())), true
+	}
+	if v.Type().Implements(stringerType) && v.CanInterface() {
+		return String(k, v.Interface().(fmt.Stringer).String()), true
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return Bool(k, v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int64(k, v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return Int64(k, int64(u)), true
+		}
+	case reflect.Float32, reflect.Float64:
+		return Float64(k, v.Float()), true
+	case reflect.String:
+		return String(k, v.String()), true
+	case reflect.Array, reflect.Slice:
+		if kv := Array(k, v.Interface()); kv.Valid() {
+			return kv, true
+		}
+	}
+	return KeyValue{}, false
+}
+
+// indirect returns the value v points to or holds if v is a pointer or an
+// interface, and false if it is nil.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal"
+)
+
+func handledErrors(t *testing.T) *[]error {
+	var errs []error
+	orig := internal.Handle
+	internal.Handle = func(err error) { errs = append(errs, err) }
+	t.Cleanup(func() { internal.Handle = orig })
+	return &errs
+}
+
+type Embedded struct {
+	Region string
+}
+
+type address struct {
+	Host string
+	Port uint16
+	// Nested is too deep to be flattened.
+	Nested struct{ Deep bool }
+}
+
+type request struct {
+	Embedded
+	Method   string
+	Retries  int
+	Size     int64
+	Ratio    float32
+	Secure   bool
+	Timeout  time.Duration
+	IP       net.IP
+	Tags     []string
+	Server   address
+	Proxy    *address
+	Headers  map[string]string
+	Callback func()
+	hidden   string
+}
+
+func TestFromStruct(t *testing.T) {
+	errs := handledErrors(t)
+	r := &request{
+		Embedded: Embedded{Region: "eu"},
+		Method:   "GET",
+		Retries:  2,
+		Size:     1024,
+		Ratio:    0.5,
+		Secure:   true,
+		Timeout:  time.Second,
+		IP:       net.IPv4(127, 0, 0, 1),
+		Tags:     []string{"a", "b"},
+		Server:   address{Host: "example.com", Port: 443},
+		Headers:  map[string]string{"k": "v"},
+		hidden:   "hidden",
+	}
+
+	want := []attribute.KeyValue{
+		attribute.String("http.Region", "eu"),
+		attribute.String("http.Method", "GET"),
+		attribute.Int64("http.Retries", 2),
+		attribute.Int64("http.Size", 1024),
+		attribute.Float64("http.Ratio", 0.5),
+		attribute.Bool("http.Secure", true),
+		attribute.Duration("http.Timeout", time.Second),
+		attribute.String("http.IP", "127.0.0.1"),
+		attribute.Array("http.Tags", []string{"a", "b"}),
+		attribute.String("http.Server.Host", "example.com"),
+		attribute.Int64("http.Server.Port", 443),
+	}
+	assert.Equal(t, want, attribute.FromStruct("http", r))
+	assert.Equal(t, want, attribute.FromStruct("http", *r))
+
+	if assert.Len(t, *errs, 6) {
+		assert.EqualError(t, (*errs)[0], "attribute: FromStruct skipped field http.Server.Nested of unsupported type struct { Deep bool }")
+		assert.EqualError(t, (*errs)[1], "attribute: FromStruct skipped field http.Headers of unsupported type map[string]string")
+		assert.EqualError(t, (*errs)[2], "attribute: FromStruct skipped field http.Callback of unsupported type func()")
+	}
+}
+
+func TestFromStructPointerField(t *testing.T) {
+	handledErrors(t)
+	r := request{Proxy: &address{Host: "proxy", Port: 8080}}
+	kvs := attribute.FromStruct("", r)
+	assert.Contains(t, kvs, attribute.String("Proxy.Host", "proxy"))
+	assert.Contains(t, kvs, attribute.Int64("Proxy.Port", 8080))
+	assert.Contains(t, kvs, attribute.String("Method", ""))
+}
+
+func TestFromStructNonStruct(t *testing.T) {
+	errs := handledErrors(t)
+	assert.Nil(t, attribute.FromStruct("p", 42))
+	assert.Nil(t, attribute.FromStruct("p", (*request)(nil)))
+	assert.Nil(t, attribute.FromStruct("p", nil))
+	assert.Len(t, *errs, 3)
+}
@@ -14,11 +14,13 @@ func _() {
 	_ = x[FLOAT64-3]
 	_ = x[STRING-4]
 	_ = x[ARRAY-5]
+	_ = x[DURATION-6]
+	_ = x[BYTES-7]
 }
 
-const _Type_name = "INVALIDBOOLINT64FLOAT64STRINGARRAY"
+const _Type_name = "INVALIDBOOLINT64FLOAT64STRINGARRAYDURATIONBYTES"
 
-var _Type_index = [...]uint8{0, 7, 11, 16, 23, 29, 34}
+var _Type_index = [...]uint8{0, 7, 11, 16, 23, 29, 34, 42, 47}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
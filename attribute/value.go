@@ -15,10 +15,12 @@
 package attribute // import "go.opentelemetry.io/otel/attribute"
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/otel/internal"
 )
@@ -53,6 +55,11 @@ const (
 	// arrays of bool, int, int32, int64, uint, uint32, uint64, float,
 	// float32, float64, or string types.
 	ARRAY
+	// DURATION is a time.Duration Type Value stored as a 64-bit signed
+	// integral number of nanoseconds.
+	DURATION
+	// BYTES is a byte slice Type Value.
+	BYTES
 )
 
 // BoolValue creates a BOOL Value.
@@ -92,6 +99,22 @@ func IntValue(v int) Value {
 	return Int64Value(int64(v))
 }
 
+// DurationValue creates a DURATION Value.
+func DurationValue(v time.Duration) Value {
+	return Value{
+		vtype:   DURATION,
+		numeric: internal.Int64ToRaw(int64(v)),
+	}
+}
+
+// BytesValue creates a BYTES Value. The Value holds a copy of v.
+func BytesValue(v []byte) Value {
+	return Value{
+		vtype:    BYTES,
+		stringly: string(v),
+	}
+}
+
 // ArrayValue creates an ARRAY value from an array or slice.
 // Only arrays or slices of bool, int, int64, float, float64, or string types are allowed.
 // Specifically, arrays  and slices can not contain other arrays, slices, structs, or non-standard
@@ -138,6 +161,12 @@ func (v Value) AsInt64() int64 {
 	return internal.RawToInt64(v.numeric)
 }
 
+// AsDuration returns the time.Duration value. Make sure that the Value's
+// type is DURATION.
+func (v Value) AsDuration() time.Duration {
+	return time.Duration(internal.RawToInt64(v.numeric))
+}
+
 // AsFloat64 returns the float64 value. Make sure that the Value's
 // type is FLOAT64.
 func (v Value) AsFloat64() float64 {
@@ -150,6 +179,12 @@ func (v Value) AsString() string {
 	return v.stringly
 }
 
+// AsBytes returns a copy of the byte slice value. Make sure that the
+// Value's type is BYTES.
+func (v Value) AsBytes() []byte {
+	return []byte(v.stringly)
+}
+
 // AsArray returns the array Value as an interface{}.
 func (v Value) AsArray() interface{} {
 	return v.array
@@ -170,6 +205,10 @@ func (v Value) AsInterface() interface{} {
 		return v.AsFloat64()
 	case STRING:
 		return v.stringly
+	case DURATION:
+		return v.AsDuration()
+	case BYTES:
+		return v.AsBytes()
 	}
 	return unknownValueType{}
 }
@@ -187,18 +226,30 @@ func (v Value) Emit() string {
 		return fmt.Sprint(v.AsFloat64())
 	case STRING:
 		return v.stringly
+	case DURATION:
+		return v.AsDuration().String()
+	case BYTES:
+		return base64.StdEncoding.EncodeToString([]byte(v.stringly))
 	default:
 		return "unknown"
 	}
 }
 
 // MarshalJSON returns the JSON encoding of the Value.
+//
+// A DURATION Value is encoded as its integral number of nanoseconds along
+// with a "Unit" field set to "ns". A BYTES Value is encoded as a base64
+// string, as encoding/json encodes byte slices.
 func (v Value) MarshalJSON() ([]byte, error) {
 	var jsonVal struct {
 		Type  string
 		Value interface{}
+		Unit  string `json:",omitempty"`
 	}
 	jsonVal.Type = v.Type().String()
 	jsonVal.Value = v.AsInterface()
+	if v.Type() == DURATION {
+		jsonVal.Unit = "ns"
+	}
 	return json.Marshal(jsonVal)
 }
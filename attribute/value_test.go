@@ -15,8 +15,10 @@
 package attribute_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -56,6 +58,18 @@ func TestValue(t *testing.T) {
 			wantType:  attribute.FLOAT64,
 			wantValue: 42.1,
 		},
+		{
+			name:      "Key.Duration() correctly returns keys's internal duration value",
+			value:     k.Duration(1500 * time.Millisecond).Value,
+			wantType:  attribute.DURATION,
+			wantValue: 1500 * time.Millisecond,
+		},
+		{
+			name:      "Key.Bytes() correctly returns keys's internal bytes value",
+			value:     k.Bytes([]byte{0xde, 0xad}).Value,
+			wantType:  attribute.BYTES,
+			wantValue: []byte{0xde, 0xad},
+		},
 		{
 			name:      "Key.String() correctly returns keys's internal string value",
 			value:     k.String("foo").Value,
@@ -136,3 +150,44 @@ func TestAsArrayValue(t *testing.T) {
 		t.Errorf("AsArray() returned %T, want %T", got, want)
 	}
 }
+
+func TestAsDuration(t *testing.T) {
+	v := attribute.DurationValue(-3 * time.Second)
+	if got, want := v.AsDuration(), -3*time.Second; got != want {
+		t.Errorf("AsDuration() returned %v, want %v", got, want)
+	}
+}
+
+func TestDurationValueMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(attribute.DurationValue(1500 * time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"Type":"DURATION","Value":1500000000,"Unit":"ns"}`; got != want {
+		t.Errorf("MarshalJSON() returned %s, want %s", got, want)
+	}
+}
+
+func TestAsBytes(t *testing.T) {
+	b := []byte{0xca, 0xfe}
+	v := attribute.BytesValue(b)
+	b[0] = 0
+	got := v.AsBytes()
+	if want := []byte{0xca, 0xfe}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AsBytes() returned %v, want %v", got, want)
+	}
+	got[1] = 0
+	if want := []byte{0xca, 0xfe}; !reflect.DeepEqual(v.AsBytes(), want) {
+		t.Errorf("AsBytes() returned %v after modifying a returned slice, want %v", v.AsBytes(), want)
+	}
+}
+
+func TestBytesValueMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(attribute.BytesValue([]byte("fingerprint")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"Type":"BYTES","Value":"ZmluZ2VycHJpbnQ="}`; got != want {
+		t.Errorf("MarshalJSON() returned %s, want %s", got, want)
+	}
+}
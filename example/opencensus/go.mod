@@ -34,6 +34,8 @@ replace go.opentelemetry.io/otel/example/prometheus => ../prometheus
 
 replace go.opentelemetry.io/otel/example/zipkin => ../zipkin
 
+replace go.opentelemetry.io/otel/exporters/autoexport => ../../exporters/autoexport
+
 replace go.opentelemetry.io/otel/exporters/metric/prometheus => ../../exporters/metric/prometheus
 
 replace go.opentelemetry.io/otel/exporters/otlp => ../../exporters/otlp
@@ -44,6 +46,8 @@ replace go.opentelemetry.io/otel/exporters/trace/zipkin => ../../exporters/trace
 
 replace go.opentelemetry.io/otel/internal/tools => ../../internal/tools
 
+replace go.opentelemetry.io/otel/instrumentation/otelgrpc => ../../instrumentation/otelgrpc
+
 replace go.opentelemetry.io/otel/metric => ../../metric
 
 replace go.opentelemetry.io/otel/oteltest => ../../oteltest
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoexport // import "go.opentelemetry.io/otel/exporters/autoexport"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracesExporterKey is the name of the environment variable selecting the
+// span exporter.
+const tracesExporterKey = "OTEL_TRACES_EXPORTER"
+
+// NewFromEnv returns the span exporter selected by the OTEL_TRACES_EXPORTER
+// environment variable:
+//   - "stdout": a stdout exporter with its default configuration.
+//   - "otlp": an OTLP exporter using a gRPC driver configured by the
+//     OTEL_EXPORTER_OTLP_* environment variables.
+//   - "none": an exporter dropping all spans.
+//
+// The value of the variable is not case sensitive. If it is not set or empty
+// the stdout exporter is returned. An error is returned for any other value.
+//
+// The passed ctx is used to start the OTLP exporter.
+func NewFromEnv(ctx context.Context) (sdktrace.SpanExporter, error) {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv(tracesExporterKey)))
+	var (
+		exp sdktrace.SpanExporter
+		err error
+	)
+	switch v {
+	case "", "stdout":
+		exp, err = stdout.NewExporter()
+	case "otlp":
+		exp, err = otlpgrpc.NewExporter(ctx)
+	case "none":
+		exp = noopExporter{}
+	default:
+		return nil, fmt.Errorf("unsupported %s value: %q", tracesExporterKey, v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+// noopExporter is a span exporter dropping all spans.
+type noopExporter struct{}
+
+var _ sdktrace.SpanExporter = noopExporter{}
+
+func (noopExporter) ExportSpans(context.Context, []*sdktrace.SpanSnapshot) error { return nil }
+
+func (noopExporter) Shutdown(context.Context) error { return nil }
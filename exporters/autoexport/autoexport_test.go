@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  sdktrace.SpanExporter
+	}{
+		{name: "unset", value: "", want: &stdout.Exporter{}},
+		{name: "stdout", value: "stdout", want: &stdout.Exporter{}},
+		{name: "upper case", value: "STDOUT", want: &stdout.Exporter{}},
+		{name: "otlp", value: "otlp", want: &otlp.Exporter{}},
+		{name: "none", value: "none", want: noopExporter{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envStore, err := ottest.SetEnvVariables(map[string]string{
+				tracesExporterKey:             tt.value,
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost:4317",
+			})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, envStore.Restore()) }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			exp, err := NewFromEnv(ctx)
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, exp)
+			assert.NoError(t, exp.Shutdown(ctx))
+		})
+	}
+}
+
+func TestNewFromEnvUnknownExporter(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		tracesExporterKey: "unknown",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	exp, err := NewFromEnv(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, exp)
+}
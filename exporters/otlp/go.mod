@@ -41,6 +41,8 @@ replace go.opentelemetry.io/otel/example/prometheus => ../../example/prometheus
 
 replace go.opentelemetry.io/otel/example/zipkin => ../../example/zipkin
 
+replace go.opentelemetry.io/otel/exporters/autoexport => ../autoexport
+
 replace go.opentelemetry.io/otel/exporters/metric/prometheus => ../metric/prometheus
 
 replace go.opentelemetry.io/otel/exporters/otlp => ./
@@ -53,6 +55,8 @@ replace go.opentelemetry.io/otel/exporters/trace/zipkin => ../trace/zipkin
 
 replace go.opentelemetry.io/otel/internal/tools => ../../internal/tools
 
+replace go.opentelemetry.io/otel/instrumentation/otelgrpc => ../../instrumentation/otelgrpc
+
 replace go.opentelemetry.io/otel/metric => ../../metric
 
 replace go.opentelemetry.io/otel/oteltest => ../../oteltest
@@ -64,6 +64,10 @@ func toAttribute(v attribute.KeyValue) *commonpb.KeyValue {
 		result.Value.Value = &commonpb.AnyValue_IntValue{
 			IntValue: v.Value.AsInt64(),
 		}
+	case attribute.DURATION:
+		result.Value.Value = &commonpb.AnyValue_IntValue{
+			IntValue: int64(v.Value.AsDuration()),
+		}
 	case attribute.FLOAT64:
 		result.Value.Value = &commonpb.AnyValue_DoubleValue{
 			DoubleValue: v.Value.AsFloat64(),
@@ -72,6 +76,12 @@ func toAttribute(v attribute.KeyValue) *commonpb.KeyValue {
 		result.Value.Value = &commonpb.AnyValue_StringValue{
 			StringValue: v.Value.AsString(),
 		}
+	case attribute.BYTES:
+		// This version of the protocol has no bytes value, use the base64
+		// encoding of the bytes.
+		result.Value.Value = &commonpb.AnyValue_StringValue{
+			StringValue: v.Value.Emit(),
+		}
 	case attribute.ARRAY:
 		result.Value.Value = &commonpb.AnyValue_ArrayValue{
 			ArrayValue: &commonpb.ArrayValue{
@@ -16,6 +16,7 @@ package transform
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -38,6 +39,8 @@ func TestAttributes(t *testing.T) {
 				attribute.Float64("float64 to double", 1.61),
 				attribute.String("string to string", "string"),
 				attribute.Bool("bool to bool", true),
+				attribute.Duration("duration to int64", 2*time.Second),
+				attribute.Bytes("bytes to string", []byte("bytes")),
 			},
 			[]*commonpb.KeyValue{
 				{
@@ -80,6 +83,22 @@ func TestAttributes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Key: "duration to int64",
+					Value: &commonpb.AnyValue{
+						Value: &commonpb.AnyValue_IntValue{
+							IntValue: 2000000000,
+						},
+					},
+				},
+				{
+					Key: "bytes to string",
+					Value: &commonpb.AnyValue{
+						Value: &commonpb.AnyValue_StringValue{
+							StringValue: "Ynl0ZXM=",
+						},
+					},
+				},
 			},
 		},
 	} {
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp"
+)
+
+// NewExporter creates a new OTLP exporter sending traces and metrics to the
+// collector over a gRPC connection configured by opts. It is equivalent to
+// calling otlp.NewExporter with a driver created by NewDriver(opts...).
+//
+// The connection to the collector is established in the background, and
+// re-established after failure, at the period set with
+// WithReconnectionPeriod. Exports failing with a retryable status, e.g.
+// codes.Unavailable, are retried with an exponential backoff according to
+// the WithRetry option. Shutdown of the exporter closes the connection.
+func NewExporter(ctx context.Context, opts ...Option) (*otlp.Exporter, error) {
+	return otlp.NewExporter(ctx, NewDriver(opts...))
+}
@@ -576,6 +576,29 @@ func TestNewExporter_withHeaders(t *testing.T) {
 	assert.Equal(t, "value1", headers.Get("header1")[0])
 }
 
+func TestNewExporter_otlpgrpcNewExporter(t *testing.T) {
+	mc := runMockCollector(t)
+	defer func() {
+		_ = mc.stop()
+	}()
+
+	ctx := context.Background()
+	exp, err := otlpgrpc.NewExporter(ctx,
+		otlpgrpc.WithInsecure(),
+		otlpgrpc.WithEndpoint(mc.endpoint),
+		otlpgrpc.WithHeaders(map[string]string{"header1": "value1"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(ctx, []*sdktrace.SpanSnapshot{{Name: "in the midst"}}))
+
+	require.Len(t, mc.getSpans(), 1)
+	assert.Equal(t, "in the midst", mc.getSpans()[0].Name)
+	assert.Equal(t, []string{"value1"}, mc.getHeaders().Get("header1"))
+
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Error(t, exp.ExportSpans(ctx, []*sdktrace.SpanSnapshot{{Name: "after shutdown"}}))
+}
+
 func TestNewExporter_WithTimeout(t *testing.T) {
 	tts := []struct {
 		name    string
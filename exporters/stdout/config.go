@@ -15,10 +15,14 @@
 package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
 )
 
 var (
@@ -28,8 +32,32 @@ var (
 	defaultLabelEncoder        = attribute.DefaultEncoder()
 	defaultDisableTraceExport  = false
 	defaultDisableMetricExport = false
+	defaultDedupSize           = 1024
+	defaultEscapeHTML          = true
+	defaultSampledField        = false
+	defaultOmitEmpty           = false
+	defaultBatchSequence       = false
+	defaultMaxSpansPerWrite    = 0
+	defaultFlattenSlices       = false
+	defaultMaxBytesPerWrite    = 0
+	defaultLineTerminator      = "\n"
+	defaultDryRun              = false
+	defaultResourceLabels      = true
+	defaultSortedEvents        = false
+	defaultStreaming           = false
+	defaultTimestampFormat     = time.RFC3339Nano
+	defaultEpochTimestamps     = false
+	defaultUnixNanoTimestamps  = false
+	defaultEventsAsRecords     = false
+	defaultGroupByResource     = false
+	defaultOTLPShape           = false
+	defaultOTLPProtobuf        = false
+	defaultOTLPJSON            = false
 )
 
+// maxLineTerminatorLen is the maximum length in bytes of a line terminator.
+const maxLineTerminatorLen = 2
+
 // Config contains options for the STDOUT exporter.
 type Config struct {
 	// Writer is the destination.  If not set, os.Stdout is used.
@@ -51,6 +79,112 @@ type Config struct {
 
 	// DisableMetricExport prevents any export of metric telemetry.
 	DisableMetricExport bool
+
+	// EscapeHTML specifies if the problematic HTML characters <, >, and &
+	// are escaped in the JSON output. Default is true.
+	EscapeHTML bool
+
+	// SampledField specifies if a boolean Sampled field is added to the
+	// span contexts of exported spans. Default is false.
+	SampledField bool
+
+	// OmitEmpty specifies if span fields with a null, false, zero, or
+	// empty value are omitted from the output. Default is false.
+	OmitEmpty bool
+
+	// BatchSequence specifies if the spans of every export are wrapped in an
+	// object with an increasing batch sequence number. Default is false.
+	BatchSequence bool
+
+	// MaxBytesPerWrite is the maximum size in bytes of a single write.
+	// Larger exports are split into multiple writes. Default is 0, meaning
+	// the size of writes is not limited.
+	MaxBytesPerWrite int
+
+	// FlattenSlices specifies if array attribute values are written as one
+	// attribute per element, with the index of the element appended to the
+	// key. Default is false.
+	FlattenSlices bool
+
+	// MaxSpansPerWrite is the maximum number of spans written in a single
+	// JSON array. Larger exports are split into multiple writes. Default is
+	// 0, meaning exports are never split.
+	MaxSpansPerWrite int
+
+	// LineTerminator is written after every write of exported telemetry.
+	// It is empty or consists of at most two ASCII control characters.
+	// Default is "\n".
+	LineTerminator string
+
+	// DryRun specifies if the encoded telemetry is discarded instead of
+	// written to the Writer. Default is false.
+	DryRun bool
+
+	// ResourceAsConstantLabels specifies if the attributes of the resource
+	// of exported metrics are added as labels to every metric. Default is
+	// true.
+	ResourceAsConstantLabels bool
+
+	// SortedEvents specifies if the events of exported spans are written in
+	// chronological order. Default is false, meaning events are written in
+	// the order they were added.
+	SortedEvents bool
+
+	// Streaming specifies if the spans of every write are encoded and
+	// written one at a time instead of being encoded as a whole before
+	// being written. It cannot be used with MaxBytesPerWrite. Default is
+	// false.
+	Streaming bool
+
+	// TimestampFormat is the layout, as accepted by time.Time.Format, time
+	// values are written with. Default is time.RFC3339Nano, the format of
+	// the JSON encoding of time.Time.
+	TimestampFormat string
+
+	// EpochTimestamps specifies if time values are written as integer
+	// nanoseconds since the Unix epoch instead of with TimestampFormat.
+	// Default is false.
+	EpochTimestamps bool
+
+	// UnixNanoTimestamps specifies if the time fields of spans and events
+	// are replaced with fields suffixed with UnixNano, as in OTLP, holding
+	// integer nanoseconds since the Unix epoch. Default is false.
+	UnixNanoTimestamps bool
+
+	// EventsAsRecords specifies if the events of the written spans are also
+	// written as separate records. Default is false.
+	EventsAsRecords bool
+
+	// GroupByResource specifies if the spans of a write are grouped by
+	// resource. Default is false.
+	GroupByResource bool
+
+	// OTLPShape specifies if the spans of a write are grouped by resource
+	// and then by instrumentation library, like in OTLP. Default is false.
+	OTLPShape bool
+
+	// OTLPProtobuf specifies if the spans of a write are written as a
+	// length-prefixed OTLP protobuf message instead of JSON. Default is
+	// false.
+	OTLPProtobuf bool
+
+	// OTLPJSON specifies if the spans of a write are written as an OTLP/JSON
+	// export request instead of SpanSnapshots. Default is false.
+	OTLPJSON bool
+
+	// ExportResultCallback is called with the result of the export of
+	// every exported span. Default is nil.
+	ExportResultCallback func(*trace.SpanSnapshot, error)
+
+	// DedupSize is the number of recently exported spans remembered to
+	// skip duplicate exports of the same span. Default is 0, meaning no
+	// deduplication is performed.
+	DedupSize int
+
+	// ResourceAttributeFilter, if not nil, selects the resource attributes
+	// of the spans that are written: those it returns false for are
+	// omitted. Default is nil, meaning all are written.
+	ResourceAttributeFilter attribute.Filter
 }
 
 // NewConfig creates a validated Config configured with options.
@@ -62,14 +196,81 @@ func NewConfig(options ...Option) (Config, error) {
 		LabelEncoder:        defaultLabelEncoder,
 		DisableTraceExport:  defaultDisableTraceExport,
 		DisableMetricExport: defaultDisableMetricExport,
+		EscapeHTML:          defaultEscapeHTML,
+		SampledField:        defaultSampledField,
+		OmitEmpty:           defaultOmitEmpty,
+		BatchSequence:       defaultBatchSequence,
+		MaxSpansPerWrite:    defaultMaxSpansPerWrite,
+		FlattenSlices:       defaultFlattenSlices,
+		MaxBytesPerWrite:    defaultMaxBytesPerWrite,
+		LineTerminator:      defaultLineTerminator,
+		DryRun:              defaultDryRun,
+
+		ResourceAsConstantLabels: defaultResourceLabels,
+		SortedEvents:             defaultSortedEvents,
+		Streaming:                defaultStreaming,
+		TimestampFormat:          defaultTimestampFormat,
+		EpochTimestamps:          defaultEpochTimestamps,
+		UnixNanoTimestamps:       defaultUnixNanoTimestamps,
+		EventsAsRecords:          defaultEventsAsRecords,
+		GroupByResource:          defaultGroupByResource,
+		OTLPShape:                defaultOTLPShape,
+		OTLPProtobuf:             defaultOTLPProtobuf,
+		OTLPJSON:                 defaultOTLPJSON,
 	}
 	for _, opt := range options {
 		opt.Apply(&config)
 
 	}
+	if err := validateLineTerminator(config.LineTerminator); err != nil {
+		return config, err
+	}
+	if config.Streaming && config.MaxBytesPerWrite > 0 {
+		return config, errStreamingMaxBytes
+	}
+	if config.Streaming && (config.GroupByResource || config.OTLPShape) {
+		return config, errStreamingGroupByResource
+	}
+	if (config.OTLPProtobuf || config.OTLPJSON) && (config.Streaming || config.MaxBytesPerWrite > 0) {
+		return config, errOTLPStreaming
+	}
+	if config.OTLPProtobuf && config.OTLPJSON {
+		return config, errOTLPProtobufJSON
+	}
 	return config, nil
 }
 
+// validateLineTerminator returns an error if term is not a valid
+// LineTerminator.
+func validateLineTerminator(term string) error {
+	if len(term) > maxLineTerminatorLen {
+		return fmt.Errorf("stdout: line terminator %q is longer than %d bytes", term, maxLineTerminatorLen)
+	}
+	for i := 0; i < len(term); i++ {
+		if c := term[i]; c >= 0x20 && c != 0x7f {
+			return fmt.Errorf("stdout: line terminator %q contains non-control characters", term)
+		}
+	}
+	return nil
+}
+
+// errStreamingMaxBytes is returned if both Streaming and MaxBytesPerWrite
+// are set: the size of a streamed write is only known once written.
+var errStreamingMaxBytes = errors.New("stdout: streaming cannot be used with a maximum write size")
+
+// errStreamingGroupByResource is returned if both Streaming and
+// GroupByResource are set: the groups are only known once all spans are
+// encoded.
+var errStreamingGroupByResource = errors.New("stdout: streaming cannot be used with grouping by resource")
+
+// errOTLPStreaming is returned if OTLPProtobuf or OTLPJSON is set with
+// Streaming or MaxBytesPerWrite: an OTLP export request is encoded and
+// written as a whole.
+var errOTLPStreaming = errors.New("stdout: OTLP output cannot be used with streaming or a maximum write size")
+
+// errOTLPProtobufJSON is returned if both OTLPProtobuf and OTLPJSON are set.
+var errOTLPProtobufJSON = errors.New("stdout: OTLP protobuf and OTLP JSON outputs cannot be used together")
+
 // Option sets the value of an option for a Config.
 type Option interface {
 	// Apply option value to Config.
@@ -122,6 +323,384 @@ func (o timestampsOption) Apply(config *Config) {
 
 func (timestampsOption) private() {}
 
+// WithoutHTMLEscaping sets the export stream to not escape the HTML
+// characters <, >, and & in the JSON output. This keeps values like URLs
+// readable.
+func WithoutHTMLEscaping() Option {
+	return escapeHTMLOption(false)
+}
+
+type escapeHTMLOption bool
+
+func (o escapeHTMLOption) Apply(config *Config) {
+	config.EscapeHTML = bool(o)
+}
+
+func (escapeHTMLOption) private() {}
+
+// WithSampledField sets the export stream to include a boolean Sampled
+// field, reporting if the sampled flag is set, alongside the TraceFlags of
+// every exported span context.
+func WithSampledField() Option {
+	return sampledFieldOption(true)
+}
+
+type sampledFieldOption bool
+
+func (o sampledFieldOption) Apply(config *Config) {
+	config.SampledField = bool(o)
+}
+
+func (sampledFieldOption) private() {}
+
+// WithOmitEmpty sets the export stream to omit the fields of spans that
+// have a null, false, zero, or empty value, like the omitempty option of the
+// encoding/json package would. Only the fields of the spans themselves are
+// omitted, the values they contain are written in full.
+func WithOmitEmpty() Option {
+	return omitEmptyOption(true)
+}
+
+type omitEmptyOption bool
+
+func (o omitEmptyOption) Apply(config *Config) {
+	config.OmitEmpty = bool(o)
+}
+
+func (omitEmptyOption) private() {}
+
+// WithBatchSequence sets the export stream to wrap the spans of every export
+// in an object holding a sequence number of the export, starting at 1, and,
+// unless WithoutTimestamps is used, the time of the export. The spans are
+// written as {"batch":1,"timestamp":"...","spans":[...]}. This makes the
+// boundaries of exports visible in large outputs.
+func WithBatchSequence() Option {
+	return batchSequenceOption(true)
+}
+
+type batchSequenceOption bool
+
+func (o batchSequenceOption) Apply(config *Config) {
+	config.BatchSequence = bool(o)
+}
+
+func (batchSequenceOption) private() {}
+
+// WithFlattenSlices sets the export stream to write every array attribute of
+// the spans, their events, links, and resources as one attribute per
+// element. The attribute tags=[a,b,c] is written as the attributes tags.0=a,
+// tags.1=b, and tags.2=c. This helps consumers not supporting array values.
+func WithFlattenSlices() Option {
+	return flattenSlicesOption(true)
+}
+
+type flattenSlicesOption bool
+
+func (o flattenSlicesOption) Apply(config *Config) {
+	config.FlattenSlices = bool(o)
+}
+
+func (flattenSlicesOption) private() {}
+
+// WithMaxBytesPerWrite sets the export stream to split exports encoding to
+// more than n bytes into multiple writes of at most n bytes each, not
+// counting the line terminator. Exports are only split between spans, a
+// single span encoding to more than n bytes is written on its own and
+// reported to the global error handler. If n is not positive, the size of
+// writes is not limited.
+func WithMaxBytesPerWrite(n int) Option {
+	return maxBytesPerWriteOption(n)
+}
+
+type maxBytesPerWriteOption int
+
+func (o maxBytesPerWriteOption) Apply(config *Config) {
+	config.MaxBytesPerWrite = int(o)
+}
+
+func (maxBytesPerWriteOption) private() {}
+
+// WithMaxSpansPerWrite sets the export stream to split exports of more than
+// n spans into multiple writes of at most n spans each. Every write is a
+// separate JSON array, and a separate batch if WithBatchSequence is used. If
+// n is not positive, exports are not split.
+func WithMaxSpansPerWrite(n int) Option {
+	return maxSpansPerWriteOption(n)
+}
+
+type maxSpansPerWriteOption int
+
+func (o maxSpansPerWriteOption) Apply(config *Config) {
+	config.MaxSpansPerWrite = int(o)
+}
+
+func (maxSpansPerWriteOption) private() {}
+
+// WithLineTerminator sets the export stream to write term after every write
+// of exported telemetry instead of a newline, e.g. "\r\n", or "" to not
+// terminate writes. The term must consist of at most two ASCII control
+// characters, otherwise creating the exporter fails.
+func WithLineTerminator(term string) Option {
+	return lineTerminatorOption(term)
+}
+
+type lineTerminatorOption string
+
+func (o lineTerminatorOption) Apply(config *Config) {
+	config.LineTerminator = string(o)
+}
+
+func (lineTerminatorOption) private() {}
+
+// WithDryRun sets the export stream to encode all exported telemetry as
+// usual and then discard it instead of writing it to the Writer. The
+// exported spans are still counted by the ExportedCount method of the
+// Exporter. This isolates the encoding cost of the exporter from the cost of
+// writing the output, e.g. when benchmarking.
+func WithDryRun() Option {
+	return dryRunOption(true)
+}
+
+type dryRunOption bool
+
+func (o dryRunOption) Apply(config *Config) {
+	config.DryRun = bool(o)
+}
+
+func (dryRunOption) private() {}
+
+// WithResourceAsConstantLabels sets if the attributes of the resource of
+// exported metrics are added as labels to every metric, as is done by
+// default. Passing false omits the resource from the exported metrics to
+// limit the size and cardinality of the output.
+func WithResourceAsConstantLabels(enabled bool) Option {
+	return resourceLabelsOption(enabled)
+}
+
+type resourceLabelsOption bool
+
+func (o resourceLabelsOption) Apply(config *Config) {
+	config.ResourceAsConstantLabels = bool(o)
+}
+
+func (resourceLabelsOption) private() {}
+
+// WithSortedEvents sets the export stream to write the events of every span
+// in chronological order. Events added with explicit timestamps can be out
+// of order, they are stably sorted by time so events with the same time keep
+// the order they were added in.
+func WithSortedEvents() Option {
+	return sortedEventsOption(true)
+}
+
+type sortedEventsOption bool
+
+func (o sortedEventsOption) Apply(config *Config) {
+	config.SortedEvents = bool(o)
+}
+
+func (sortedEventsOption) private() {}
+
+// WithStreaming sets the export stream to encode the spans of every write
+// one at a time and write them to the Writer as they are encoded, instead of
+// encoding all of them before writing. The output is the same, but the
+// memory used no longer grows with the size of exports. This cannot be used
+// with WithMaxBytesPerWrite, creating the exporter fails if both are used.
+func WithStreaming() Option {
+	return streamingOption(true)
+}
+
+type streamingOption bool
+
+func (o streamingOption) Apply(config *Config) {
+	config.Streaming = bool(o)
+}
+
+func (streamingOption) private() {}
+
+// WithTimestampFormat sets the export stream to write all time values, the
+// start and end times of spans, the times of their events, and the
+// timestamps of batches and metrics, formatted with layout as accepted by
+// time.Time.Format instead of time.RFC3339Nano.
+func WithTimestampFormat(layout string) Option {
+	return timestampFormatOption(layout)
+}
+
+type timestampFormatOption string
+
+func (o timestampFormatOption) Apply(config *Config) {
+	config.TimestampFormat = string(o)
+	config.EpochTimestamps = false
+}
+
+func (timestampFormatOption) private() {}
+
+// WithEpochTimestamps sets the export stream to write all time values, the
+// start and end times of spans, the times of their events, and the
+// timestamps of batches and metrics, as integer nanoseconds since the Unix
+// epoch. Zero time values are written as 0.
+func WithEpochTimestamps() Option {
+	return epochTimestampsOption(true)
+}
+
+type epochTimestampsOption bool
+
+func (o epochTimestampsOption) Apply(config *Config) {
+	config.EpochTimestamps = bool(o)
+}
+
+func (epochTimestampsOption) private() {}
+
+// WithUnixNanoTimestamps sets the export stream to write the start and end
+// times of spans as StartTimeUnixNano and EndTimeUnixNano fields, and the
+// times of events as TimeUnixNano fields, holding integer nanoseconds since
+// the Unix epoch, like the OTLP wire format does. They replace the
+// StartTime, EndTime, and Time fields. The timestamps of batches and metrics
+// are written as integer nanoseconds since the Unix epoch too. Zero time
+// values are written as 0.
+func WithUnixNanoTimestamps() Option {
+	return unixNanoTimestampsOption(true)
+}
+
+type unixNanoTimestampsOption bool
+
+func (o unixNanoTimestampsOption) Apply(config *Config) {
+	config.UnixNanoTimestamps = bool(o)
+}
+
+func (unixNanoTimestampsOption) private() {}
+
+// WithEventsAsRecords sets the export stream to write every event of the
+// written spans as a separate JSON record, following the write of the spans.
+// The records hold the trace and span IDs and the name of the span of the
+// event to correlate them with the span, and the name, time, and attributes
+// of the event. This allows log tools to process the events like log lines.
+// The spans are written with their events as usual.
+func WithEventsAsRecords() Option {
+	return eventsAsRecordsOption(true)
+}
+
+type eventsAsRecordsOption bool
+
+func (o eventsAsRecordsOption) Apply(config *Config) {
+	config.EventsAsRecords = bool(o)
+}
+
+func (eventsAsRecordsOption) private() {}
+
+// WithGroupByResource sets the export stream to write the spans of a write
+// grouped by resource, like the OTLP ResourceSpans: instead of an array of
+// spans, an array of {"resource":[...],"spans":[...]} objects is written,
+// one per distinct resource in the order they first appear. The spans are
+// written without their Resource field. It cannot be used with
+// WithStreaming.
+func WithGroupByResource() Option {
+	return groupByResourceOption(true)
+}
+
+type groupByResourceOption bool
+
+func (o groupByResourceOption) Apply(config *Config) {
+	config.GroupByResource = bool(o)
+}
+
+func (groupByResourceOption) private() {}
+
+// WithOTLPShape sets the export stream to write the spans of a write
+// grouped by resource, and by instrumentation library within each resource
+// group, like the OTLP ResourceSpans and ScopeSpans: an array of
+// {"resource":[...],"scopeSpans":[{"scope":{...},"spans":[...]}]} objects is
+// written. The scope holds the name and version of the instrumentation
+// library, the spans without instrumentation library are grouped in a scope
+// with an empty name. The groups are in the order they first appear and the
+// spans are written without their Resource and InstrumentationLibrary
+// fields. It cannot be used with WithStreaming.
+func WithOTLPShape() Option {
+	return otlpShapeOption(true)
+}
+
+type otlpShapeOption bool
+
+func (o otlpShapeOption) Apply(config *Config) {
+	config.OTLPShape = bool(o)
+}
+
+func (otlpShapeOption) private() {}
+
+// WithOTLPProtobuf sets the export stream to write the spans of a write as
+// the protobuf encoding of an OTLP ExportTraceServiceRequest instead of
+// JSON. This captures raw OTLP payloads, e.g. to a file to later replay them
+// into a collector.
+//
+// Every message is framed by a prefix holding its length in bytes encoded
+// as an unsigned varint, as encoding/binary.PutUvarint and the protobuf
+// delimited format do, and is not followed by the line terminator. The
+// messages are read back with:
+//
+//	r := bufio.NewReader(dump)
+//	n, err := binary.ReadUvarint(r)
+//	// handle err, io.EOF once all messages are read.
+//	msg := make([]byte, n)
+//	_, err = io.ReadFull(r, msg)
+//
+// The JSON encoding options, e.g. WithPrettyPrint, WithOTLPShape, or
+// WithEventsAsRecords, have no effect. It cannot be used with WithStreaming,
+// WithMaxBytesPerWrite, or WithOTLPJSON.
+func WithOTLPProtobuf() Option {
+	return otlpProtobufOption(true)
+}
+
+type otlpProtobufOption bool
+
+func (o otlpProtobufOption) Apply(config *Config) {
+	config.OTLPProtobuf = bool(o)
+}
+
+func (otlpProtobufOption) private() {}
+
+// WithOTLPJSON sets the export stream to write the spans of a write as the
+// OTLP/JSON encoding of an OTLP ExportTraceServiceRequest instead of
+// SpanSnapshots. This output is ingestible by OTLP aware tooling, e.g. a
+// collector. The requests hold resourceSpans, grouping the spans by resource
+// and then in instrumentationLibrarySpans by instrumentation library, with
+// camelCase field names, hex encoded trace and span IDs, enum values as
+// integers, and timestamps in nanoseconds since the Unix epoch.
+//
+// Every request is written on its own line, indented if WithPrettyPrint is
+// used. The other JSON encoding options, e.g. WithOTLPShape or
+// WithTimestampFormat, have no effect. It cannot be used with WithStreaming,
+// WithMaxBytesPerWrite, or WithOTLPProtobuf.
+func WithOTLPJSON() Option {
+	return otlpJSONOption(true)
+}
+
+type otlpJSONOption bool
+
+func (o otlpJSONOption) Apply(config *Config) {
+	config.OTLPJSON = bool(o)
+}
+
+func (otlpJSONOption) private() {}
+
+// WithExportResultCallback sets the export stream to call f with the result
+// of the export of every span. Spans failing to be encoded, which are not
+// written, are passed to f with the encoding error. The written spans are
+// passed to f with a nil error once written, or with the error of the Writer
+// if writing them failed. The spans passed to f must not be modified, they
+// are copies of the exported ones if WithFlattenSlices, WithSortedEvents, or
+// WithResourceAttributeFilter changed them.
+func WithExportResultCallback(f func(span *trace.SpanSnapshot, err error)) Option {
+	return exportResultCallbackOption(f)
+}
+
+type exportResultCallbackOption func(*trace.SpanSnapshot, error)
+
+func (o exportResultCallbackOption) Apply(config *Config) {
+	config.ExportResultCallback = o
+}
+
+func (exportResultCallbackOption) private() {}
+
 // WithLabelEncoder sets the label encoder used in export.
 func WithLabelEncoder(enc attribute.Encoder) Option {
 	return labelEncoderOption{enc}
@@ -162,3 +741,41 @@ func (o disableMetricExportOption) Apply(config *Config) {
 }
 
 func (disableMetricExportOption) private() {}
+
+// WithResourceAttributeFilter sets the export stream to write only the
+// resource attributes of the spans that keep returns true for, e.g. to omit
+// sensitive infrastructure details from local dumps. The other attributes
+// are omitted from the Resource of the spans, and from the resources of
+// WithGroupByResource and WithOTLPShape, whose spans are grouped by their
+// filtered resources. The filter is applied once per resource for every
+// export.
+func WithResourceAttributeFilter(keep func(attribute.KeyValue) bool) Option {
+	return resourceAttributeFilterOption(keep)
+}
+
+type resourceAttributeFilterOption attribute.Filter
+
+func (o resourceAttributeFilterOption) Apply(config *Config) {
+	config.ResourceAttributeFilter = attribute.Filter(o)
+}
+
+func (resourceAttributeFilterOption) private() {}
+
+// WithDedup enables skipping spans that were recently exported. Up to size
+// spans, identified by their trace and span IDs, are remembered in a
+// least-recently-used cache. If size is not positive, a default size of 1024
+// is used.
+func WithDedup(size int) Option {
+	if size <= 0 {
+		size = defaultDedupSize
+	}
+	return dedupOption(size)
+}
+
+type dedupOption int
+
+func (o dedupOption) Apply(config *Config) {
+	config.DedupSize = int(o)
+}
+
+func (dedupOption) private() {}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"container/list"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	apitrace "go.opentelemetry.io/otel/trace"
+)
+
+// spanKey uniquely identifies an exported span.
+type spanKey struct {
+	traceID apitrace.TraceID
+	spanID  apitrace.SpanID
+}
+
+// dedupCache is a capped set of recently exported spans. Eviction is done
+// via a LRU method, the oldest entry is removed to create room for a new
+// entry.
+type dedupCache struct {
+	mu       sync.Mutex
+	keys     map[spanKey]*list.Element
+	lru      *list.List
+	capacity int
+}
+
+// newDedupCache returns a dedupCache holding up to capacity entries. If
+// capacity is not positive, nil is returned.
+func newDedupCache(capacity int) *dedupCache {
+	if capacity <= 0 {
+		return nil
+	}
+	return &dedupCache{
+		keys:     make(map[spanKey]*list.Element),
+		lru:      list.New(),
+		capacity: capacity,
+	}
+}
+
+// filter returns the spans from ss that have not been recently exported and
+// records them as exported.
+func (c *dedupCache) filter(ss []*trace.SpanSnapshot) []*trace.SpanSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]*trace.SpanSnapshot, 0, len(ss))
+	for _, s := range ss {
+		if s == nil {
+			continue
+		}
+		k := spanKey{
+			traceID: s.SpanContext.TraceID(),
+			spanID:  s.SpanContext.SpanID(),
+		}
+		if ent, ok := c.keys[k]; ok {
+			c.lru.MoveToFront(ent)
+			continue
+		}
+		c.keys[k] = c.lru.PushFront(k)
+		if c.lru.Len() > c.capacity {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.keys, oldest.Value.(spanKey))
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -15,6 +15,13 @@
 // Package stdout contains an OpenTelemetry exporter for both tracing and
 // metric telemetry to be written to an output destination as JSON.
 //
+// Attribute values are written as objects holding the attribute Type and
+// Value. Duration attribute values are written as an integral number of
+// nanoseconds along with a Unit field, e.g.
+// {"Type":"DURATION","Value":1500000000,"Unit":"ns"}. Bytes attribute
+// values are written as a base64 string, as encoding/json writes byte
+// slices, e.g. {"Type":"BYTES","Value":"3q2+7w=="}.
+//
 // This package is currently in a pre-GA phase. Backwards incompatible changes
 // may be introduced in subsequent minor version releases as we work to track
 // the evolving OpenTelemetry specification and user feedback.
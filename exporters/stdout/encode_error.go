@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanEncodeError is the error of a span that could not be encoded.
+type SpanEncodeError struct {
+	// Span is the span that could not be encoded.
+	Span *trace.SpanSnapshot
+	// Err is the error encoding the span failed with.
+	Err error
+}
+
+func (e *SpanEncodeError) Error() string {
+	return fmt.Sprintf("stdout: encoding span %s failed: %v", e.Span.SpanContext.SpanID(), e.Err)
+}
+
+// Unwrap returns the error encoding the span failed with.
+func (e *SpanEncodeError) Unwrap() error {
+	return e.Err
+}
+
+// SpanEncodeErrors is returned by ExportSpans when spans of the export could
+// not be encoded. These spans are not written, the other spans are.
+type SpanEncodeErrors []*SpanEncodeError
+
+func (e SpanEncodeErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("stdout: %d spans could not be encoded: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// encodeError is the error of a write that failed to be encoded before
+// anything was written.
+type encodeError struct {
+	err error
+}
+
+func (e encodeError) Error() string { return e.err.Error() }
+func (e encodeError) Unwrap() error { return e.err }
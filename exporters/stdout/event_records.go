@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	apitrace "go.opentelemetry.io/otel/trace"
+)
+
+// eventRecord is an event written as a separate record.
+type eventRecord struct {
+	TraceID  apitrace.TraceID
+	SpanID   apitrace.SpanID
+	SpanName string
+	Name     string
+	// Note: these are interfaces because omitempty doesn't work when
+	// time.IsZero(). Only one of them is set, according to the timestamp
+	// options.
+	Time         interface{}          `json:"Time,omitempty"`
+	TimeUnixNano interface{}          `json:"TimeUnixNano,omitempty"`
+	Attributes   []attribute.KeyValue `json:"Attributes,omitempty"`
+}
+
+// writeEventRecords writes the events of ss as separate records if
+// EventsAsRecords is set.
+func (e *traceExporter) writeEventRecords(ss []*trace.SpanSnapshot) error {
+	return e.forEachEventRecord(ss, func(out []byte) error {
+		return writeLine(e.config, out)
+	})
+}
+
+// forEachEventRecord calls emit in order with the encoding of the records
+// of the events of ss if EventsAsRecords is set.
+func (e *traceExporter) forEachEventRecord(ss []*trace.SpanSnapshot, emit func(out []byte) error) error {
+	if !e.config.EventsAsRecords {
+		return nil
+	}
+	for _, s := range ss {
+		for _, ev := range s.MessageEvents {
+			r := eventRecord{
+				TraceID:    s.SpanContext.TraceID(),
+				SpanID:     s.SpanContext.SpanID(),
+				SpanName:   s.Name,
+				Name:       ev.Name,
+				Attributes: ev.Attributes,
+			}
+			if t := timestampValue(e.config, ev.Time); e.config.UnixNanoTimestamps {
+				r.TimeUnixNano = t
+			} else {
+				r.Time = t
+			}
+			out, err := e.marshal(r)
+			if err != nil {
+				return err
+			}
+			if err := emit(out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -15,7 +15,9 @@
 package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric/global"
@@ -43,7 +45,10 @@ func NewExporter(options ...Option) (*Exporter, error) {
 		return nil, err
 	}
 	return &Exporter{
-		traceExporter:  traceExporter{config: config},
+		traceExporter: traceExporter{
+			config: config,
+			dedup:  newDedupCache(config.DedupSize),
+		},
 		metricExporter: metricExporter{config},
 	}, nil
 }
@@ -94,3 +99,79 @@ func InstallNewPipeline(exportOpts []Option, pushOpts []controller.Option) (*sdk
 	global.SetMeterProvider(controller.MeterProvider())
 	return tracerProvider, controller, err
 }
+
+// marshal returns the JSON encoding of v formatted according to config.
+func marshal(config Config, v interface{}) ([]byte, error) {
+	return marshalIndent(config, v, "")
+}
+
+// marshalIndent returns the JSON encoding of v formatted according to config.
+// If PrettyPrint is set, every line but the first one begins with prefix.
+func marshalIndent(config Config, v interface{}, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(config.EscapeHTML)
+	if config.PrettyPrint {
+		enc.SetIndent(prefix, "\t")
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encode terminates the value with a newline, the caller decides how to
+	// terminate the output.
+	out := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if !config.EscapeHTML {
+		// Values implementing json.Marshaler, like attribute values, escape
+		// HTML characters regardless of the encoder settings.
+		out = unescapeHTML(out)
+	}
+	return out, nil
+}
+
+// writeLine writes out terminated by the configured LineTerminator to the
+// configured Writer. Nothing is written if DryRun is set.
+func writeLine(config Config, out []byte) error {
+	if config.DryRun {
+		return nil
+	}
+	line := make([]byte, 0, len(out)+len(config.LineTerminator))
+	line = append(append(line, out...), config.LineTerminator...)
+	_, err := config.Writer.Write(line)
+	return err
+}
+
+// unescapeHTML replaces the \u003c, \u003e, and \u0026 escape sequences in
+// the JSON encoded data with the characters they represent.
+func unescapeHTML(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`\u00`)) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 == len(data) {
+			out = append(out, data[i])
+			continue
+		}
+		if i+5 < len(data) {
+			switch string(data[i : i+6]) {
+			case `\u003c`:
+				out = append(out, '<')
+				i += 5
+				continue
+			case `\u003e`:
+				out = append(out, '>')
+				i += 5
+				continue
+			case `\u0026`:
+				out = append(out, '&')
+				i += 5
+				continue
+			}
+		}
+		// Copy any other escape sequence as is. This ensures an escaped
+		// backslash is not interpreted as the start of a sequence.
+		out = append(out, data[i], data[i+1])
+		i++
+	}
+	return out
+}
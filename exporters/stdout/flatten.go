@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"reflect"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// flattenSlices returns copies of ss with the array attributes of the
+// spans, their events, links, and resources replaced by one attribute per
+// element.
+func flattenSlices(ss []*trace.SpanSnapshot) []*trace.SpanSnapshot {
+	out := make([]*trace.SpanSnapshot, len(ss))
+	for i, s := range ss {
+		c := s.Clone()
+		c.Attributes = flattenAttributes(c.Attributes)
+		for j := range c.MessageEvents {
+			c.MessageEvents[j].Attributes = flattenAttributes(c.MessageEvents[j].Attributes)
+		}
+		for j := range c.Links {
+			c.Links[j].Attributes = flattenAttributes(c.Links[j].Attributes)
+		}
+		if c.Resource != nil && hasArray(c.Resource.Attributes()) {
+			c.Resource = resource.NewWithAttributes(flattenAttributes(c.Resource.Attributes())...)
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// flattenAttributes returns kvs with every array attribute key=[a,b] replaced
+// by the attributes key.0=a and key.1=b.
+func flattenAttributes(kvs []attribute.KeyValue) []attribute.KeyValue {
+	if !hasArray(kvs) {
+		return kvs
+	}
+	out := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		if kv.Value.Type() != attribute.ARRAY {
+			out = append(out, kv)
+			continue
+		}
+		arr := reflect.ValueOf(kv.Value.AsArray())
+		for i := 0; i < arr.Len(); i++ {
+			key := string(kv.Key) + "." + strconv.Itoa(i)
+			out = append(out, attribute.Any(key, arr.Index(i).Interface()))
+		}
+	}
+	return out
+}
+
+func hasArray(kvs []attribute.KeyValue) bool {
+	for _, kv := range kvs {
+		if kv.Value.Type() == attribute.ARRAY {
+			return true
+		}
+	}
+	return false
+}
@@ -16,10 +16,7 @@ package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"strings"
-	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -41,8 +38,9 @@ type line struct {
 	Count     interface{} `json:"Count,omitempty"`
 	LastValue interface{} `json:"Last,omitempty"`
 
-	// Note: this is a pointer because omitempty doesn't work when time.IsZero()
-	Timestamp *time.Time `json:"Timestamp,omitempty"`
+	// Note: this is an interface because omitempty doesn't work when
+	// time.IsZero()
+	Timestamp interface{} `json:"Timestamp,omitempty"`
 }
 
 func (e *metricExporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) exportmetric.ExportKind {
@@ -59,7 +57,10 @@ func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.Ch
 		desc := record.Descriptor()
 		agg := record.Aggregation()
 		kind := desc.NumberKind()
-		encodedResource := record.Resource().Encoded(e.config.LabelEncoder)
+		var encodedResource string
+		if e.config.ResourceAsConstantLabels {
+			encodedResource = record.Resource().Encoded(e.config.LabelEncoder)
+		}
 
 		var instLabels []attribute.KeyValue
 		if name := desc.InstrumentationName(); name != "" {
@@ -107,7 +108,7 @@ func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.Ch
 			expose.LastValue = value.AsInterface(kind)
 
 			if e.config.Timestamps {
-				expose.Timestamp = &timestamp
+				expose.Timestamp = timestampValue(e.config, timestamp)
 			}
 		}
 
@@ -148,15 +149,14 @@ func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.Ch
 	if err != nil {
 		return err
 	}
-	fmt.Fprintln(e.config.Writer, string(data))
+	if err := writeLine(e.config, data); err != nil {
+		return err
+	}
 
 	return aggError
 }
 
 // marshal v with approriate indentation.
 func (e *metricExporter) marshal(v interface{}) ([]byte, error) {
-	if e.config.PrettyPrint {
-		return json.MarshalIndent(v, "", "\t")
-	}
-	return json.Marshal(v)
+	return marshal(e.config, v)
 }
@@ -127,6 +127,38 @@ func TestStdoutTimestamp(t *testing.T) {
 	assert.True(t, lastValueTimestamp.Before(after))
 }
 
+func TestStdoutEpochTimestamps(t *testing.T) {
+	var buf bytes.Buffer
+	exporter, err := stdout.NewExporter(
+		stdout.WithWriter(&buf),
+		stdout.WithEpochTimestamps(),
+	)
+	if err != nil {
+		t.Fatal("Invalid config: ", err)
+	}
+
+	before := time.Now()
+	checkpointSet := metrictest.NewCheckpointSet(testResource)
+	desc := metric.NewDescriptor("test.name", metric.ValueObserverInstrumentKind, number.Int64Kind)
+	lvagg, ckpt := metrictest.Unslice2(lastvalue.New(2))
+	aggregatortest.CheckedUpdate(t, lvagg, number.NewInt64Number(321), &desc)
+	require.NoError(t, lvagg.SynchronizedMove(ckpt, &desc))
+	checkpointSet.Add(&desc, ckpt)
+
+	if err := exporter.Export(context.Background(), checkpointSet); err != nil {
+		t.Fatal("Unexpected export error: ", err)
+	}
+	after := time.Now()
+
+	var printed []struct{ Timestamp int64 }
+	if err := json.Unmarshal(buf.Bytes(), &printed); err != nil {
+		t.Fatal("JSON parse error: ", err)
+	}
+	require.Len(t, printed, 1)
+	assert.GreaterOrEqual(t, printed[0].Timestamp, before.UnixNano())
+	assert.LessOrEqual(t, printed[0].Timestamp, after.UnixNano())
+}
+
 func TestStdoutCounterFormat(t *testing.T) {
 	fix := newFixture(t)
 
@@ -307,3 +339,28 @@ func TestStdoutResource(t *testing.T) {
 		require.Equal(t, `[{"Name":"test.name{`+tc.expect+`}","Last":123.456}]`, fix.Output())
 	}
 }
+
+func TestStdoutWithResourceAsConstantLabels(t *testing.T) {
+	for _, tc := range []struct {
+		enabled bool
+		expect  string
+	}{
+		{enabled: true, expect: `[{"Name":"test.name{R=V,A=B}","Sum":123}]`},
+		{enabled: false, expect: `[{"Name":"test.name{A=B}","Sum":123}]`},
+	} {
+		fix := newFixture(t, stdout.WithResourceAsConstantLabels(tc.enabled))
+
+		checkpointSet := metrictest.NewCheckpointSet(testResource)
+
+		desc := metric.NewDescriptor("test.name", metric.CounterInstrumentKind, number.Int64Kind)
+		cagg, ckpt := metrictest.Unslice2(sum.New(2))
+		aggregatortest.CheckedUpdate(fix.t, cagg, number.NewInt64Number(123), &desc)
+		require.NoError(t, cagg.SynchronizedMove(ckpt, &desc))
+
+		checkpointSet.Add(&desc, ckpt, attribute.String("A", "B"))
+
+		fix.Export(checkpointSet)
+
+		require.Equal(t, tc.expect, fix.Output())
+	}
+}
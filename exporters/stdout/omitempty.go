@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonField is a member of a JSON object.
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonObject is a JSON object that retains the order of its members.
+type jsonObject []jsonField
+
+// MarshalJSON encodes the members of o in order.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(f.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// omitEmpty returns the objects of the JSON array v encodes to with all
+// members having an empty value removed.
+func omitEmpty(v interface{}) ([]jsonObject, error) {
+	elems, err := arrayElements(v)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]jsonObject, len(elems))
+	for i, elem := range elems {
+		if objs[i], err = nonEmptyFields(elem); err != nil {
+			return nil, err
+		}
+	}
+	return objs, nil
+}
+
+// arrayElements returns the elements of the JSON array v encodes to.
+func arrayElements(v interface{}) ([]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return nil, err
+	}
+	return elems, nil
+}
+
+// nonEmptyFields returns the members of the JSON object data that do not
+// have an empty value.
+func nonEmptyFields(data json.RawMessage) (jsonObject, error) {
+	obj, err := decodeObject(data)
+	if err != nil {
+		return nil, err
+	}
+	nonEmpty := obj[:0]
+	for _, f := range obj {
+		if !isEmptyJSON(f.value) {
+			nonEmpty = append(nonEmpty, f)
+		}
+	}
+	return nonEmpty, nil
+}
+
+// decodeObject returns the members of the JSON object data in order.
+func decodeObject(data json.RawMessage) (jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, fmt.Errorf("stdout: expected JSON object, got %v", t)
+	}
+
+	var obj jsonObject
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := t.(string)
+		if !ok {
+			return nil, fmt.Errorf("stdout: expected JSON object key, got %v", t)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		obj = append(obj, jsonField{key: key, value: value})
+	}
+	return obj, nil
+}
+
+// isEmptyJSON returns if the compact JSON value data is null, false, zero,
+// or an empty string, array, or object.
+func isEmptyJSON(data json.RawMessage) bool {
+	switch string(data) {
+	case "null", "false", "0", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
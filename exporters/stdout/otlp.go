@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// writeOTLP writes ss as a single OTLP ExportTraceServiceRequest, encoded
+// by encodeOTLP.
+func (e *traceExporter) writeOTLP(ss []*trace.SpanSnapshot) error {
+	out, err := e.encodeOTLP(ss)
+	if err != nil {
+		return err
+	}
+	if !e.config.DryRun {
+		_, err = e.config.Writer.Write(out)
+	}
+	e.reportResults(ss, err)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&e.exported, uint64(len(ss)))
+	return nil
+}
+
+// encodeOTLP returns the output of the write of ss as a single OTLP
+// ExportTraceServiceRequest: a protobuf message prefixed by its varint
+// encoded length with OTLPProtobuf, an OTLP/JSON object terminated by the
+// LineTerminator with OTLPJSON.
+func (e *traceExporter) encodeOTLP(ss []*trace.SpanSnapshot) ([]byte, error) {
+	req := newOTLPRequest(ss)
+	if e.config.OTLPProtobuf {
+		msg := req.marshalProto()
+		out := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(msg))
+		return append(out[:binary.PutUvarint(out, uint64(len(msg)))], msg...), nil
+	}
+
+	out, err := marshal(e.config, req)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, e.config.LineTerminator...), nil
+}
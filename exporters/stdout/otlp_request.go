@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	apitrace "go.opentelemetry.io/otel/trace"
+)
+
+// The OTLP ExportTraceServiceRequest, and the messages it holds, are
+// encoded by this package to not depend on the OTLP exporter and the gRPC
+// service definitions. Their fields are those of the OTLP protocol as sent
+// by the go.opentelemetry.io/otel/exporters/otlp exporter, the JSON field
+// names and values follow OTLP/JSON.
+
+// maxOTLPEventsPerSpan is the maximum number of events of a span sent by the
+// OTLP exporter.
+const maxOTLPEventsPerSpan = 128
+
+// The protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// The OTLP span status codes.
+const (
+	otlpStatusOk    = 1
+	otlpStatusError = 2
+)
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans,omitempty"`
+}
+
+type otlpResourceSpans struct {
+	Resource                    *otlpResource       `json:"resource,omitempty"`
+	InstrumentationLibrarySpans []*otlpLibrarySpans `json:"instrumentationLibrarySpans,omitempty"`
+
+	// libraries indexes InstrumentationLibrarySpans by instrumentation
+	// library.
+	libraries map[instrumentation.Library]*otlpLibrarySpans
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLibrarySpans struct {
+	InstrumentationLibrary *otlpLibrary `json:"instrumentationLibrary,omitempty"`
+	Spans                  []*otlpSpan  `json:"spans,omitempty"`
+}
+
+type otlpLibrary struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID                string         `json:"traceId,omitempty"`
+	SpanID                 string         `json:"spanId,omitempty"`
+	TraceState             string         `json:"traceState,omitempty"`
+	ParentSpanID           string         `json:"parentSpanId,omitempty"`
+	Name                   string         `json:"name,omitempty"`
+	Kind                   uint64         `json:"kind,omitempty"`
+	StartTimeUnixNano      uint64         `json:"startTimeUnixNano,string,omitempty"`
+	EndTimeUnixNano        uint64         `json:"endTimeUnixNano,string,omitempty"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount uint64         `json:"droppedAttributesCount,omitempty"`
+	Events                 []otlpEvent    `json:"events,omitempty"`
+	DroppedEventsCount     uint64         `json:"droppedEventsCount,omitempty"`
+	Links                  []otlpLink     `json:"links,omitempty"`
+	DroppedLinksCount      uint64         `json:"droppedLinksCount,omitempty"`
+	Status                 otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano uint64         `json:"timeUnixNano,string,omitempty"`
+	Name         string         `json:"name,omitempty"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId,omitempty"`
+	SpanID     string         `json:"spanId,omitempty"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message,omitempty"`
+	Code    uint64 `json:"code,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key,omitempty"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds one of its values, the others are nil.
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *int64          `json:"intValue,string,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values,omitempty"`
+}
+
+// newOTLPRequest returns the OTLP ExportTraceServiceRequest exporting ss.
+// The spans are grouped by resource, and then by instrumentation library,
+// in the order the resources, and libraries, first appear in ss.
+func newOTLPRequest(ss []*trace.SpanSnapshot) *otlpRequest {
+	req := &otlpRequest{}
+	index := make(map[attribute.Distinct]*otlpResourceSpans)
+	for _, s := range ss {
+		if s == nil {
+			continue
+		}
+		rs, ok := index[s.Resource.Equivalent()]
+		if !ok {
+			rs = &otlpResourceSpans{libraries: make(map[instrumentation.Library]*otlpLibrarySpans)}
+			if s.Resource != nil {
+				rs.Resource = &otlpResource{Attributes: otlpAttributes(s.Resource.Attributes())}
+			}
+			index[s.Resource.Equivalent()] = rs
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+		}
+		ls, ok := rs.libraries[s.InstrumentationLibrary]
+		if !ok {
+			ls = &otlpLibrarySpans{}
+			if lib := s.InstrumentationLibrary; lib != (instrumentation.Library{}) {
+				ls.InstrumentationLibrary = &otlpLibrary{Name: lib.Name, Version: lib.Version}
+			}
+			rs.libraries[s.InstrumentationLibrary] = ls
+			rs.InstrumentationLibrarySpans = append(rs.InstrumentationLibrarySpans, ls)
+		}
+		ls.Spans = append(ls.Spans, newOTLPSpan(s))
+	}
+	return req
+}
+
+func newOTLPSpan(s *trace.SpanSnapshot) *otlpSpan {
+	tid := s.SpanContext.TraceID()
+	sid := s.SpanContext.SpanID()
+	span := &otlpSpan{
+		TraceID:                hex.EncodeToString(tid[:]),
+		SpanID:                 hex.EncodeToString(sid[:]),
+		TraceState:             s.SpanContext.TraceState().String(),
+		Name:                   s.Name,
+		Kind:                   otlpSpanKind(s.SpanKind),
+		StartTimeUnixNano:      uint64(s.StartTime.UnixNano()),
+		EndTimeUnixNano:        uint64(s.EndTime.UnixNano()),
+		Attributes:             otlpAttributes(s.Attributes),
+		DroppedAttributesCount: uint64(uint32(s.DroppedAttributeCount)),
+		DroppedEventsCount:     uint64(uint32(s.DroppedMessageEventCount)),
+		DroppedLinksCount:      uint64(uint32(s.DroppedLinkCount)),
+		Status:                 otlpStatus{Message: s.StatusMessage, Code: otlpStatusOk},
+	}
+	if psid := s.Parent.SpanID(); psid.IsValid() {
+		span.ParentSpanID = psid.String()
+	}
+	if s.StatusCode == codes.Error {
+		span.Status.Code = otlpStatusError
+	}
+	for i, e := range s.MessageEvents {
+		if i == maxOTLPEventsPerSpan {
+			break
+		}
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: uint64(e.Time.UnixNano()),
+			Name:         e.Name,
+			Attributes:   otlpAttributes(e.Attributes),
+		})
+	}
+	for _, l := range s.Links {
+		tid, sid := l.TraceID(), l.SpanID()
+		span.Links = append(span.Links, otlpLink{
+			TraceID:    hex.EncodeToString(tid[:]),
+			SpanID:     hex.EncodeToString(sid[:]),
+			Attributes: otlpAttributes(l.Attributes),
+		})
+	}
+	return span
+}
+
+// otlpSpanKind returns the OTLP span kind of kind.
+func otlpSpanKind(kind apitrace.SpanKind) uint64 {
+	switch kind {
+	case apitrace.SpanKindInternal, apitrace.SpanKindServer, apitrace.SpanKindClient,
+		apitrace.SpanKindProducer, apitrace.SpanKindConsumer:
+		// The OTLP span kinds have the values of the API span kinds.
+		return uint64(kind)
+	}
+	return 0
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		kvs = append(kvs, otlpKeyValue{Key: string(kv.Key), Value: otlpValue(kv.Value)})
+	}
+	return kvs
+}
+
+// otlpValue returns the OTLP value of v, as sent by the OTLP exporter.
+func otlpValue(v attribute.Value) otlpAnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return otlpAnyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := v.AsInt64()
+		return otlpAnyValue{IntValue: &i}
+	case attribute.DURATION:
+		i := int64(v.AsDuration())
+		return otlpAnyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return otlpAnyValue{DoubleValue: &f}
+	case attribute.STRING:
+		s := v.AsString()
+		return otlpAnyValue{StringValue: &s}
+	case attribute.BYTES:
+		s := base64.StdEncoding.EncodeToString(v.AsBytes())
+		return otlpAnyValue{StringValue: &s}
+	case attribute.ARRAY:
+		return otlpAnyValue{ArrayValue: otlpArray(reflect.ValueOf(v.AsArray()))}
+	}
+	s := "INVALID"
+	return otlpAnyValue{StringValue: &s}
+}
+
+func otlpArray(a reflect.Value) *otlpArrayValue {
+	values := make([]otlpAnyValue, a.Len())
+	for i := range values {
+		e := a.Index(i)
+		switch e.Kind() {
+		case reflect.Bool:
+			b := e.Bool()
+			values[i].BoolValue = &b
+		case reflect.Int, reflect.Int64:
+			n := e.Int()
+			values[i].IntValue = &n
+		case reflect.Uintptr:
+			n := int64(e.Uint())
+			values[i].IntValue = &n
+		case reflect.Float64:
+			f := e.Float()
+			values[i].DoubleValue = &f
+		case reflect.String:
+			s := e.String()
+			values[i].StringValue = &s
+		}
+	}
+	return &otlpArrayValue{Values: values}
+}
+
+// marshalProto returns the protobuf encoding of the request.
+func (r *otlpRequest) marshalProto() []byte {
+	var b []byte
+	for _, rs := range r.ResourceSpans {
+		b = appendMessage(b, 1, rs.appendProto)
+	}
+	return b
+}
+
+func (rs *otlpResourceSpans) appendProto(b []byte) []byte {
+	if rs.Resource != nil {
+		b = appendMessage(b, 1, rs.Resource.appendProto)
+	}
+	for _, ls := range rs.InstrumentationLibrarySpans {
+		b = appendMessage(b, 2, ls.appendProto)
+	}
+	return b
+}
+
+func (r *otlpResource) appendProto(b []byte) []byte {
+	return appendKeyValues(b, 1, r.Attributes)
+}
+
+func (ls *otlpLibrarySpans) appendProto(b []byte) []byte {
+	if l := ls.InstrumentationLibrary; l != nil {
+		b = appendMessage(b, 1, func(b []byte) []byte {
+			b = appendString(b, 1, l.Name)
+			return appendString(b, 2, l.Version)
+		})
+	}
+	for _, s := range ls.Spans {
+		b = appendMessage(b, 2, s.appendProto)
+	}
+	return b
+}
+
+func (s *otlpSpan) appendProto(b []byte) []byte {
+	b = appendHexID(b, 1, s.TraceID)
+	b = appendHexID(b, 2, s.SpanID)
+	b = appendString(b, 3, s.TraceState)
+	b = appendHexID(b, 4, s.ParentSpanID)
+	b = appendString(b, 5, s.Name)
+	b = appendVarintField(b, 6, s.Kind)
+	b = appendFixed64(b, 7, s.StartTimeUnixNano)
+	b = appendFixed64(b, 8, s.EndTimeUnixNano)
+	b = appendKeyValues(b, 9, s.Attributes)
+	b = appendVarintField(b, 10, s.DroppedAttributesCount)
+	for _, e := range s.Events {
+		e := e
+		b = appendMessage(b, 11, func(b []byte) []byte {
+			b = appendFixed64(b, 1, e.TimeUnixNano)
+			b = appendString(b, 2, e.Name)
+			return appendKeyValues(b, 3, e.Attributes)
+		})
+	}
+	b = appendVarintField(b, 12, s.DroppedEventsCount)
+	for _, l := range s.Links {
+		l := l
+		b = appendMessage(b, 13, func(b []byte) []byte {
+			b = appendHexID(b, 1, l.TraceID)
+			b = appendHexID(b, 2, l.SpanID)
+			return appendKeyValues(b, 4, l.Attributes)
+		})
+	}
+	b = appendVarintField(b, 14, s.DroppedLinksCount)
+	return appendMessage(b, 15, func(b []byte) []byte {
+		b = appendString(b, 2, s.Status.Message)
+		return appendVarintField(b, 3, s.Status.Code)
+	})
+}
+
+func appendKeyValues(b []byte, num uint64, kvs []otlpKeyValue) []byte {
+	for _, kv := range kvs {
+		kv := kv
+		b = appendMessage(b, num, func(b []byte) []byte {
+			b = appendString(b, 1, kv.Key)
+			return appendMessage(b, 2, kv.Value.appendProto)
+		})
+	}
+	return b
+}
+
+// appendProto appends the set value of v, encoded even if it is the zero
+// value as it is a member of a oneof.
+func (v otlpAnyValue) appendProto(b []byte) []byte {
+	switch {
+	case v.StringValue != nil:
+		b = appendTag(b, 1, wireBytes)
+		b = appendVarint(b, uint64(len(*v.StringValue)))
+		return append(b, *v.StringValue...)
+	case v.BoolValue != nil:
+		var n uint64
+		if *v.BoolValue {
+			n = 1
+		}
+		return appendVarint(appendTag(b, 2, wireVarint), n)
+	case v.IntValue != nil:
+		return appendVarint(appendTag(b, 3, wireVarint), uint64(*v.IntValue))
+	case v.DoubleValue != nil:
+		return appendFixed64Value(appendTag(b, 4, wireFixed64), math.Float64bits(*v.DoubleValue))
+	case v.ArrayValue != nil:
+		return appendMessage(b, 5, func(b []byte) []byte {
+			for _, e := range v.ArrayValue.Values {
+				b = appendMessage(b, 1, e.appendProto)
+			}
+			return b
+		})
+	}
+	return b
+}
+
+func appendTag(b []byte, num, wireType uint64) []byte {
+	return appendVarint(b, num<<3|wireType)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func appendFixed64Value(b []byte, v uint64) []byte {
+	for i := 0; i < 8; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+// appendMessage appends the embedded message field num encoded by
+// appendFields.
+func appendMessage(b []byte, num uint64, appendFields func([]byte) []byte) []byte {
+	msg := appendFields(nil)
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(msg)))
+	return append(b, msg...)
+}
+
+// The scalar fields are only encoded if they do not hold the zero value.
+
+func appendString(b []byte, num uint64, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendHexID appends the bytes field num holding the ID hex encoded in id.
+func appendHexID(b []byte, num uint64, id string) []byte {
+	if id == "" {
+		return b
+	}
+	raw, _ := hex.DecodeString(id)
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(raw)))
+	return append(b, raw...)
+}
+
+func appendVarintField(b []byte, num, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return appendVarint(appendTag(b, num, wireVarint), v)
+}
+
+func appendFixed64(b []byte, num, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return appendFixed64Value(appendTag(b, num, wireFixed64), v)
+}
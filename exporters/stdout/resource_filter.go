@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// filterResources returns ss with the spans having resource attributes
+// rejected by keep replaced by copies with their Resource holding only the
+// kept attributes. Every distinct resource is filtered once.
+func filterResources(ss []*trace.SpanSnapshot, keep attribute.Filter) []*trace.SpanSnapshot {
+	var out []*trace.SpanSnapshot
+	// filtered holds the filtered resources, or nil for those that were
+	// not changed, keyed by the resource they were filtered from.
+	filtered := make(map[*resource.Resource]*resource.Resource)
+	for i, s := range ss {
+		if s.Resource == nil {
+			continue
+		}
+		res, ok := filtered[s.Resource]
+		if !ok {
+			res = filterResource(s.Resource, keep)
+			filtered[s.Resource] = res
+		}
+		if res == nil {
+			continue
+		}
+		if out == nil {
+			out = make([]*trace.SpanSnapshot, len(ss))
+			copy(out, ss)
+		}
+		c := *s
+		c.Resource = res
+		out[i] = &c
+	}
+	if out == nil {
+		return ss
+	}
+	return out
+}
+
+// filterResource returns a Resource holding the attributes of res kept by
+// keep, or nil if all are kept.
+func filterResource(res *resource.Resource, keep attribute.Filter) *resource.Resource {
+	attrs := res.Attributes()
+	kept := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if keep(kv) {
+			kept = append(kept, kv)
+		}
+	}
+	if len(kept) == len(attrs) {
+		return nil
+	}
+	return resource.NewWithAttributes(kept...)
+}
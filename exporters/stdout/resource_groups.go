@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// resourceGroup are the spans of a write sharing a resource, grouped by
+// instrumentation library in ScopeSpans with the OTLP shape.
+type resourceGroup struct {
+	Resource   *resource.Resource `json:"resource"`
+	Spans      []jsonObject       `json:"spans,omitempty"`
+	ScopeSpans []*scopeGroup      `json:"scopeSpans,omitempty"`
+
+	// scopes indexes ScopeSpans by instrumentation library.
+	scopes map[instrumentation.Library]*scopeGroup
+}
+
+// scopeGroup are the spans of a resource group sharing an instrumentation
+// library.
+type scopeGroup struct {
+	Scope scope        `json:"scope"`
+	Spans []jsonObject `json:"spans"`
+}
+
+// scope is the instrumentation library of a scope group.
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// groupByResource returns the objects of the JSON array of spans ss that v
+// encodes to grouped by the resource of the spans, and by their
+// instrumentation library within the resource groups if scoped is true. The
+// groups are in the order their resource, or instrumentation library, first
+// appear in ss. The fields the spans are grouped by are removed.
+func groupByResource(ss []*trace.SpanSnapshot, v interface{}, scoped bool) ([]*resourceGroup, error) {
+	elems, err := arrayElements(v)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*resourceGroup, 0, 1)
+	index := make(map[attribute.Distinct]*resourceGroup)
+	for i, elem := range elems {
+		obj, err := decodeObject(elem)
+		if err != nil {
+			return nil, err
+		}
+		fields := obj[:0]
+		for _, f := range obj {
+			if f.key == "Resource" || scoped && f.key == "InstrumentationLibrary" {
+				continue
+			}
+			fields = append(fields, f)
+		}
+
+		res := ss[i].Resource
+		if res == nil {
+			res = resource.Empty()
+		}
+		g, ok := index[res.Equivalent()]
+		if !ok {
+			g = &resourceGroup{Resource: res}
+			index[res.Equivalent()] = g
+			groups = append(groups, g)
+		}
+		if !scoped {
+			g.Spans = append(g.Spans, fields)
+			continue
+		}
+
+		lib := ss[i].InstrumentationLibrary
+		sg, ok := g.scopes[lib]
+		if !ok {
+			if g.scopes == nil {
+				g.scopes = make(map[instrumentation.Library]*scopeGroup)
+			}
+			sg = &scopeGroup{Scope: scope{Name: lib.Name, Version: lib.Version}}
+			g.scopes[lib] = sg
+			g.ScopeSpans = append(g.ScopeSpans, sg)
+		}
+		sg.Spans = append(sg.Spans, fields)
+	}
+	return groups, nil
+}
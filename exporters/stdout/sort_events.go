@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// sortEvents returns ss with the spans having events out of chronological
+// order replaced by copies with their events stably sorted by time.
+func sortEvents(ss []*trace.SpanSnapshot) []*trace.SpanSnapshot {
+	var out []*trace.SpanSnapshot
+	for i, s := range ss {
+		if eventsSorted(s.MessageEvents) {
+			continue
+		}
+		if out == nil {
+			out = make([]*trace.SpanSnapshot, len(ss))
+			copy(out, ss)
+		}
+		c := *s
+		c.MessageEvents = make([]trace.Event, len(s.MessageEvents))
+		copy(c.MessageEvents, s.MessageEvents)
+		sort.SliceStable(c.MessageEvents, func(i, j int) bool {
+			return c.MessageEvents[i].Time.Before(c.MessageEvents[j].Time)
+		})
+		out[i] = &c
+	}
+	if out == nil {
+		return ss
+	}
+	return out
+}
+
+func eventsSorted(events []trace.Event) bool {
+	for i := 1; i < len(events); i++ {
+		if events[i].Time.Before(events[i-1].Time) {
+			return false
+		}
+	}
+	return true
+}
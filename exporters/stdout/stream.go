@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// streamSpans encodes ss as a single write and writes every span to the
+// Writer as soon as it is encoded. The output is the same as the one of
+// writeSpans. The spans failing to be encoded are skipped and their errors
+// added to errs.
+func (e *traceExporter) streamSpans(ss []*trace.SpanSnapshot, errs *SpanEncodeErrors) error {
+	var seq uint64
+	if e.config.BatchSequence {
+		seq = atomic.AddUint64(&e.batchSeq, 1)
+	}
+	// The encoding of no spans is split around the empty span array to get
+	// the output written around the spans.
+	frame, err := e.encode([]*trace.SpanSnapshot{}, seq, time.Now())
+	if err != nil {
+		return err
+	}
+	i := bytes.LastIndex(frame, []byte("[]"))
+	head, tail := frame[:i+1], frame[i+1:]
+
+	// The spans are nested in the batch object with BatchSequence.
+	var prefix string
+	if e.config.PrettyPrint && e.config.BatchSequence {
+		prefix = "\t"
+	}
+
+	var w io.Writer = ioutil.Discard
+	if !e.config.DryRun {
+		w = e.config.Writer
+	}
+	bw := bufio.NewWriter(w)
+	_, _ = bw.Write(head)
+	// written holds the written spans once a span failed to be encoded.
+	var written []*trace.SpanSnapshot
+	n := 0
+	for i, s := range ss {
+		out, err := e.encodeStreamed(s, prefix+"\t")
+		if err != nil {
+			if written == nil {
+				written = make([]*trace.SpanSnapshot, i, len(ss))
+				copy(written, ss[:i])
+			}
+			e.reportEncodeError(s, err, errs)
+			continue
+		}
+		if written != nil {
+			written = append(written, s)
+		}
+		if n > 0 {
+			_ = bw.WriteByte(',')
+		}
+		if e.config.PrettyPrint {
+			_, _ = bw.WriteString("\n" + prefix + "\t")
+		}
+		// Errors of the Writer are kept and returned by Flush.
+		_, _ = bw.Write(out)
+		n++
+	}
+	if written != nil {
+		ss = written
+	}
+	if len(ss) == 0 {
+		// Nothing is written if no span could be encoded.
+		return nil
+	}
+	if e.config.PrettyPrint {
+		_, _ = bw.WriteString("\n" + prefix)
+	}
+	_, _ = bw.Write(tail)
+	_, _ = bw.WriteString(e.config.LineTerminator)
+	err = bw.Flush()
+	e.reportResults(ss, err)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&e.exported, uint64(len(ss)))
+	return e.writeEventRecords(ss)
+}
+
+// encodeStreamed returns the encoding of s as an element of a streamed span
+// array with the lines of a pretty printed encoding starting with prefix.
+func (e *traceExporter) encodeStreamed(s *trace.SpanSnapshot, prefix string) ([]byte, error) {
+	v, err := e.spanValue(s)
+	if err != nil {
+		return nil, err
+	}
+	return marshalIndent(e.config, v, prefix)
+}
+
+// spanValue returns the value s is encoded from in an array of spans.
+func (e *traceExporter) spanValue(s *trace.SpanSnapshot) (interface{}, error) {
+	var v interface{} = s
+	if e.config.SampledField {
+		v = withSampledField([]*trace.SpanSnapshot{s})[0]
+	}
+	if customTimestamps(e.config) {
+		objs, err := formatTimes(e.config, []interface{}{v})
+		if err != nil {
+			return nil, err
+		}
+		v = objs[0]
+	}
+	if e.config.OmitEmpty {
+		objs, err := omitEmpty([]interface{}{v})
+		if err != nil {
+			return nil, err
+		}
+		v = objs[0]
+	}
+	return v, nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// teeExporter is a SpanExporter fanning out its calls to several exporters.
+type teeExporter struct {
+	exporters []trace.SpanExporter
+}
+
+var _ trace.SpanExporter = (*teeExporter)(nil)
+
+// Tee returns a SpanExporter exporting the spans to all exporters, e.g. to
+// an Exporter for a local view and to an in-memory exporter for assertions,
+// without registering a span processor for each. The exporters are called
+// in order with the same spans, which they must not modify. Nil exporters
+// are ignored.
+//
+// The ExportSpans and Shutdown methods of the returned exporter call those
+// of all exporters, even if some of them fail, and return the TeeErrors of
+// the failed ones.
+func Tee(exporters ...trace.SpanExporter) trace.SpanExporter {
+	t := &teeExporter{}
+	for _, e := range exporters {
+		if e != nil {
+			t.exporters = append(t.exporters, e)
+		}
+	}
+	return t
+}
+
+// ExportSpans exports ss to all the exporters.
+func (t *teeExporter) ExportSpans(ctx context.Context, ss []*trace.SpanSnapshot) error {
+	var errs TeeErrors
+	for _, e := range t.exporters {
+		if err := e.ExportSpans(ctx, ss); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// Shutdown shuts down all the exporters.
+func (t *teeExporter) Shutdown(ctx context.Context) error {
+	var errs TeeErrors
+	for _, e := range t.exporters {
+		if err := e.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// TeeErrors are the errors of the exporters of a Tee that failed, in the
+// order the exporters were passed to Tee.
+type TeeErrors []error
+
+func (e TeeErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("stdout: %d exporters failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// errorOrNil returns e, or nil if it is empty.
+func (e TeeErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// customTimestamps returns if time values are not written the way
+// time.Time encodes to JSON.
+func customTimestamps(config Config) bool {
+	return config.EpochTimestamps || config.UnixNanoTimestamps || config.TimestampFormat != time.RFC3339Nano
+}
+
+// timestampValue returns the value t is encoded from according to config.
+func timestampValue(config Config, t time.Time) interface{} {
+	switch {
+	case config.EpochTimestamps, config.UnixNanoTimestamps:
+		if t.IsZero() {
+			return int64(0)
+		}
+		return t.UnixNano()
+	case config.TimestampFormat != time.RFC3339Nano:
+		return t.Format(config.TimestampFormat)
+	}
+	return t
+}
+
+// spanTimeFields are the time fields of encoded spans.
+var spanTimeFields = map[string]bool{"StartTime": true, "EndTime": true}
+
+// unixNanoKey returns the key a time field with key is written with
+// according to config.
+func unixNanoKey(config Config, key string) string {
+	if config.UnixNanoTimestamps {
+		return key + "UnixNano"
+	}
+	return key
+}
+
+// formatTimes returns the objects of the JSON array of spans v encodes to
+// with their time values, and the ones of their events, encoded according to
+// config.
+func formatTimes(config Config, v interface{}) ([]jsonObject, error) {
+	elems, err := arrayElements(v)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]jsonObject, len(elems))
+	for i, elem := range elems {
+		if objs[i], err = decodeObject(elem); err != nil {
+			return nil, err
+		}
+		for j, f := range objs[i] {
+			switch {
+			case spanTimeFields[f.key]:
+				f.key = unixNanoKey(config, f.key)
+				f.value, err = formatTime(config, f.value)
+			case f.key == "MessageEvents":
+				f.value, err = formatEventTimes(config, f.value)
+			}
+			if err != nil {
+				return nil, err
+			}
+			objs[i][j] = f
+		}
+	}
+	return objs, nil
+}
+
+// formatEventTimes returns the JSON array of events data with the time of
+// every event encoded according to config.
+func formatEventTimes(config Config, data json.RawMessage) (json.RawMessage, error) {
+	if string(data) == "null" {
+		return data, nil
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	objs := make([]jsonObject, len(events))
+	for i, event := range events {
+		obj, err := decodeObject(event)
+		if err != nil {
+			return nil, err
+		}
+		for j, f := range obj {
+			if f.key != "Time" {
+				continue
+			}
+			obj[j].key = unixNanoKey(config, f.key)
+			if obj[j].value, err = formatTime(config, f.value); err != nil {
+				return nil, err
+			}
+		}
+		objs[i] = obj
+	}
+	return json.Marshal(objs)
+}
+
+// formatTime returns the JSON encoded time data encoded according to config.
+func formatTime(config Config, data json.RawMessage) (json.RawMessage, error) {
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return json.Marshal(timestampValue(config, t))
+}
@@ -15,60 +15,466 @@
 package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/trace"
+	apitrace "go.opentelemetry.io/otel/trace"
 )
 
 // Exporter is an implementation of trace.SpanSyncer that writes spans to stdout.
 type traceExporter struct {
+	// The counters are accessed atomically and are kept first to ensure
+	// their 64-bit alignment.
+
+	// batchSeq is the sequence number of the last export written.
+	batchSeq uint64
+	// exported is the number of spans written.
+	exported uint64
+
 	config Config
 
 	stoppedMu sync.RWMutex
 	stopped   bool
+	// shutdownOnce ensures only the first call to Shutdown has an effect.
+	shutdownOnce sync.Once
+
+	// inFlight tracks the exports that have started and not yet completed.
+	inFlight sync.WaitGroup
+
+	// dedup holds the recently exported spans if deduplication is enabled.
+	dedup *dedupCache
 }
 
+// ErrExporterShutdown is returned when spans are exported by an exporter
+// that has been shut down.
+var ErrExporterShutdown = errors.New("stdout: exporter is shut down")
+
 // ExportSpans writes SpanSnapshots in json format to stdout.
+//
+// ErrExporterShutdown is returned, and no spans are written, if the
+// exporter has been shut down. Spans that cannot be encoded, e.g. having a
+// NaN attribute value, are not written and returned in a SpanEncodeErrors
+// once the other spans are written.
 func (e *traceExporter) ExportSpans(ctx context.Context, ss []*trace.SpanSnapshot) error {
 	e.stoppedMu.RLock()
-	stopped := e.stopped
+	if e.stopped {
+		e.stoppedMu.RUnlock()
+		return ErrExporterShutdown
+	}
+	e.inFlight.Add(1)
 	e.stoppedMu.RUnlock()
-	if stopped {
+	defer e.inFlight.Done()
+
+	if e.config.DisableTraceExport {
+		return nil
+	}
+	if e.dedup != nil {
+		ss = e.dedup.filter(ss)
+	}
+
+	var encErrs SpanEncodeErrors
+	write := func(ss []*trace.SpanSnapshot) error {
+		err := e.write(ss, e.writeSpans)
+		if !errors.As(err, new(encodeError)) {
+			return err
+		}
+		// Nothing was written, retry without the spans failing the write.
+		if ss = e.encodableSpans(ss, &encErrs); len(ss) == 0 {
+			return nil
+		}
+		return e.write(ss, e.writeSpans)
+	}
+	switch {
+	case e.config.OTLPProtobuf, e.config.OTLPJSON:
+		write = e.writeOTLP
+	case e.config.Streaming:
+		write = func(ss []*trace.SpanSnapshot) error {
+			return e.streamSpans(ss, &encErrs)
+		}
+	case e.config.MaxBytesPerWrite > 0:
+		// Writes may be split once some are written, remove the spans
+		// failing to be encoded beforehand.
+		ss = e.encodableSpans(ss, &encErrs)
+	}
+	if err := e.forEachWrite(ss, write); err != nil {
+		return err
+	}
+	if len(encErrs) > 0 {
+		return encErrs
+	}
+	return nil
+}
+
+// EncodeSpans returns the output ExportSpans writes for ss, encoded
+// according to the configured options and with every write terminated by
+// the configured line terminator. This allows sending the encoded spans over
+// another transport.
+//
+// Contrary to ExportSpans, the spans are not deduplicated and not counted as
+// exported, and the output is returned regardless of the WithDryRun and
+// WithoutTraceExport options. Batch sequence numbers are consumed the same
+// as by ExportSpans.
+func (e *traceExporter) EncodeSpans(ss []*trace.SpanSnapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	if e.config.OTLPProtobuf || e.config.OTLPJSON {
+		err := e.forEachWrite(ss, func(ss []*trace.SpanSnapshot) error {
+			out, err := e.encodeOTLP(ss)
+			buf.Write(out)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	err := e.encodeWrites(ss, func(ss []*trace.SpanSnapshot, out []byte) error {
+		buf.Write(out)
+		buf.WriteString(e.config.LineTerminator)
+		return e.forEachEventRecord(ss, func(out []byte) error {
+			buf.Write(out)
+			buf.WriteString(e.config.LineTerminator)
+			return nil
+		})
+	})
+	if err != nil {
+		var encErr encodeError
+		if errors.As(err, &encErr) {
+			err = encErr.err
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// emitFunc handles the encoded output out of a single write of ss.
+type emitFunc func(ss []*trace.SpanSnapshot, out []byte) error
+
+// encodeWrites encodes ss into the writes of at most MaxSpansPerWrite spans
+// and MaxBytesPerWrite bytes and passes them to emit in order.
+func (e *traceExporter) encodeWrites(ss []*trace.SpanSnapshot, emit emitFunc) error {
+	return e.forEachWrite(ss, func(ss []*trace.SpanSnapshot) error {
+		return e.write(ss, emit)
+	})
+}
+
+// forEachWrite prepares ss for encoding and calls write in order for every
+// group of at most MaxSpansPerWrite spans.
+func (e *traceExporter) forEachWrite(ss []*trace.SpanSnapshot, write func([]*trace.SpanSnapshot) error) error {
+	if len(ss) == 0 {
 		return nil
 	}
+	if e.config.ResourceAttributeFilter != nil {
+		ss = filterResources(ss, e.config.ResourceAttributeFilter)
+	}
+	if e.config.FlattenSlices {
+		ss = flattenSlices(ss)
+	}
+	if e.config.SortedEvents {
+		ss = sortEvents(ss)
+	}
+	n := e.config.MaxSpansPerWrite
+	if n <= 0 {
+		n = len(ss)
+	}
+	for len(ss) > 0 {
+		if n > len(ss) {
+			n = len(ss)
+		}
+		if err := write(ss[:n]); err != nil {
+			return err
+		}
+		ss = ss[n:]
+	}
+	return nil
+}
 
-	if e.config.DisableTraceExport || len(ss) == 0 {
+// write encodes ss as a single JSON array, or as multiple ones if it exceeds
+// MaxBytesPerWrite, and passes them to emit.
+func (e *traceExporter) write(ss []*trace.SpanSnapshot, emit emitFunc) error {
+	now := time.Now()
+	for max := e.config.MaxBytesPerWrite; max > 0 && len(ss) > 0; {
+		// Find the most spans fitting a single write. The size is measured
+		// with the longest possible batch sequence number to not exceed the
+		// maximum once the actual one is used.
+		n, err := e.fitting(ss, max, now)
+		if err != nil {
+			return err
+		}
+		if n == len(ss) {
+			break
+		}
+		if n == 0 {
+			otel.Handle(fmt.Errorf("stdout: span %s exceeds the maximum write size of %d bytes", ss[0].SpanContext.SpanID(), max))
+			n = 1
+		}
+		if err := e.writeEncoded(ss[:n], now, emit); err != nil {
+			return err
+		}
+		ss = ss[n:]
+	}
+	if len(ss) == 0 {
 		return nil
 	}
-	out, err := e.marshal(ss)
+	return e.writeEncoded(ss, now, emit)
+}
+
+// fitting returns the largest number of spans at the beginning of ss that
+// encode to at most max bytes.
+func (e *traceExporter) fitting(ss []*trace.SpanSnapshot, max int, now time.Time) (int, error) {
+	var err error
+	fits := func(n int) bool {
+		if err != nil {
+			return false
+		}
+		var out []byte
+		if out, err = e.encode(ss[:n], math.MaxUint64, now); err != nil {
+			err = encodeError{err}
+		}
+		return len(out) <= max
+	}
+	// Search the first n not fitting, all smaller ones fit.
+	n := sort.Search(len(ss), func(i int) bool { return !fits(i + 1) })
+	return n, err
+}
+
+func (e *traceExporter) writeEncoded(ss []*trace.SpanSnapshot, now time.Time, emit emitFunc) error {
+	var seq uint64
+	if e.config.BatchSequence {
+		seq = atomic.AddUint64(&e.batchSeq, 1)
+	}
+	out, err := e.encode(ss, seq, now)
+	if err != nil {
+		return encodeError{err}
+	}
+	return emit(ss, out)
+}
+
+// writeSpans writes the encoded output out of ss to the Writer.
+func (e *traceExporter) writeSpans(ss []*trace.SpanSnapshot, out []byte) error {
+	err := writeLine(e.config, out)
+	e.reportResults(ss, err)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&e.exported, uint64(len(ss)))
+	return e.writeEventRecords(ss)
+}
+
+// encodableSpans returns the spans of ss that can be encoded. The errors of
+// the spans that cannot are added to errs and reported to the
+// ExportResultCallback.
+func (e *traceExporter) encodableSpans(ss []*trace.SpanSnapshot, errs *SpanEncodeErrors) []*trace.SpanSnapshot {
+	var out []*trace.SpanSnapshot
+	for i, s := range ss {
+		err := e.encodeSpan(s)
+		if err == nil {
+			if out != nil {
+				out = append(out, s)
+			}
+			continue
+		}
+		if out == nil {
+			// Copy on the first failure, ss is owned by the caller.
+			out = make([]*trace.SpanSnapshot, i, len(ss))
+			copy(out, ss[:i])
+		}
+		e.reportEncodeError(s, err, errs)
+	}
+	if out == nil {
+		return ss
+	}
+	return out
+}
+
+// encodeSpan returns the error encoding s on its own fails with.
+func (e *traceExporter) encodeSpan(s *trace.SpanSnapshot) error {
+	v, err := e.spanValue(s)
 	if err != nil {
 		return err
 	}
-	_, err = fmt.Fprintln(e.config.Writer, string(out))
+	_, err = e.marshal(v)
 	return err
 }
 
-// Shutdown is called to stop the exporter, it preforms no action.
+// reportEncodeError adds the error err encoding s failed with to errs and
+// reports it to the ExportResultCallback, if any.
+func (e *traceExporter) reportEncodeError(s *trace.SpanSnapshot, err error, errs *SpanEncodeErrors) {
+	*errs = append(*errs, &SpanEncodeError{Span: s, Err: err})
+	if e.config.ExportResultCallback != nil {
+		e.config.ExportResultCallback(s, err)
+	}
+}
+
+// reportResults reports the result err of the write of ss to the
+// ExportResultCallback, if any.
+func (e *traceExporter) reportResults(ss []*trace.SpanSnapshot, err error) {
+	if e.config.ExportResultCallback == nil {
+		return
+	}
+	for _, s := range ss {
+		e.config.ExportResultCallback(s, err)
+	}
+}
+
+// ExportedCount returns the number of spans the exporter has written, or
+// discarded after encoding them if WithDryRun is used.
+func (e *traceExporter) ExportedCount() uint64 {
+	return atomic.LoadUint64(&e.exported)
+}
+
+// encode returns the JSON encoding of ss. The seq and now are the sequence
+// number and timestamp of the batch if BatchSequence is set.
+func (e *traceExporter) encode(ss []*trace.SpanSnapshot, seq uint64, now time.Time) ([]byte, error) {
+	var (
+		v   interface{} = ss
+		err error
+	)
+	if e.config.SampledField {
+		v = withSampledField(ss)
+	}
+	if customTimestamps(e.config) {
+		if v, err = formatTimes(e.config, v); err != nil {
+			return nil, err
+		}
+	}
+	if e.config.OmitEmpty {
+		if v, err = omitEmpty(v); err != nil {
+			return nil, err
+		}
+	}
+	if e.config.GroupByResource || e.config.OTLPShape {
+		if v, err = groupByResource(ss, v, e.config.OTLPShape); err != nil {
+			return nil, err
+		}
+	}
+	if e.config.BatchSequence {
+		b := batch{
+			Sequence: seq,
+			Spans:    v,
+		}
+		if e.config.Timestamps {
+			b.Timestamp = timestampValue(e.config, now)
+		}
+		v = b
+	}
+	return e.marshal(v)
+}
+
+// flusher is implemented by the Writers buffering their output, like
+// bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Shutdown is called to stop the exporter. It stops the exporter from
+// accepting new spans and waits for all in-flight exports to complete, or
+// until ctx is done. The Writer is then flushed if it has a Flush() error
+// method.
+//
+// Only the first call to Shutdown has an effect, subsequent calls return nil
+// immediately.
 func (e *traceExporter) Shutdown(ctx context.Context) error {
+	var err error
+	e.shutdownOnce.Do(func() {
+		err = e.shutdown(ctx)
+	})
+	return err
+}
+
+func (e *traceExporter) shutdown(ctx context.Context) error {
 	e.stoppedMu.Lock()
 	e.stopped = true
 	e.stoppedMu.Unlock()
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.inFlight.Wait()
+		close(done)
+	}()
 	select {
+	case <-done:
 	case <-ctx.Done():
 		return ctx.Err()
-	default:
+	}
+	if f, ok := e.config.Writer.(flusher); ok {
+		return f.Flush()
 	}
 	return nil
 }
 
 // marshal v with approriate indentation.
 func (e *traceExporter) marshal(v interface{}) ([]byte, error) {
-	if e.config.PrettyPrint {
-		return json.MarshalIndent(v, "", "\t")
+	return marshal(e.config, v)
+}
+
+// batch are the spans of a single export annotated with their sequence
+// number.
+type batch struct {
+	Sequence uint64 `json:"batch"`
+	// Note: this is an interface because omitempty doesn't work when
+	// time.IsZero()
+	Timestamp interface{} `json:"timestamp,omitempty"`
+	Spans     interface{} `json:"spans"`
+}
+
+// sampledSpanSnapshot is a SpanSnapshot encoded with a Sampled field in its
+// span contexts. The span context fields are declared before the embedded
+// SpanSnapshot so they shadow its fields and keep their position in the
+// output.
+type sampledSpanSnapshot struct {
+	SpanContext sampledSpanContext
+	Parent      sampledSpanContext
+	*trace.SpanSnapshot
+}
+
+func withSampledField(ss []*trace.SpanSnapshot) []sampledSpanSnapshot {
+	out := make([]sampledSpanSnapshot, len(ss))
+	for i, s := range ss {
+		out[i] = sampledSpanSnapshot{
+			SpanContext:  sampledSpanContext{s.SpanContext},
+			Parent:       sampledSpanContext{s.Parent},
+			SpanSnapshot: s,
+		}
 	}
-	return json.Marshal(v)
+	return out
+}
+
+// sampledSpanContext is a SpanContext encoded with a Sampled field following
+// its TraceFlags.
+type sampledSpanContext struct {
+	apitrace.SpanContext
+}
+
+// MarshalJSON encodes sc the same way as the SpanContext with an added
+// Sampled field.
+func (sc sampledSpanContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TraceID    apitrace.TraceID
+		SpanID     apitrace.SpanID
+		TraceFlags apitrace.TraceFlags
+		Sampled    bool
+		TraceState apitrace.TraceState
+		Remote     bool
+	}{
+		TraceID:    sc.TraceID(),
+		SpanID:     sc.SpanID(),
+		TraceFlags: sc.TraceFlags(),
+		Sampled:    sc.IsSampled(),
+		TraceState: sc.TraceState(),
+		Remote:     sc.IsRemote(),
+	})
 }
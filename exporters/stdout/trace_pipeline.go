@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// PipelineOption configures the TracerProvider of a trace pipeline.
+type PipelineOption func(*pipelineConfig)
+
+type pipelineConfig struct {
+	syncer    bool
+	batchOpts []sdktrace.BatchSpanProcessorOption
+	resource  *resource.Resource
+}
+
+// WithSimpleSpanProcessor exports the spans of the pipeline synchronously
+// as they end with a SimpleSpanProcessor, instead of a BatchSpanProcessor.
+func WithSimpleSpanProcessor() PipelineOption {
+	return func(c *pipelineConfig) {
+		c.syncer = true
+	}
+}
+
+// WithBatchSpanProcessor exports the spans of the pipeline with a
+// BatchSpanProcessor configured with opts. This is the default.
+func WithBatchSpanProcessor(opts ...sdktrace.BatchSpanProcessorOption) PipelineOption {
+	return func(c *pipelineConfig) {
+		c.syncer = false
+		c.batchOpts = opts
+	}
+}
+
+// WithResource sets the Resource of the TracerProvider of the pipeline. The
+// default is resource.Default().
+func WithResource(res *resource.Resource) PipelineOption {
+	return func(c *pipelineConfig) {
+		c.resource = res
+	}
+}
+
+// NewTracePipeline creates a trace export pipeline: a TracerProvider
+// exporting its spans to an Exporter created with exportOpts, with a
+// BatchSpanProcessor unless WithSimpleSpanProcessor is used. The returned
+// function flushes and shuts down the TracerProvider, and the Exporter.
+func NewTracePipeline(exportOpts []Option, opts ...PipelineOption) (*sdktrace.TracerProvider, func(context.Context) error, error) {
+	exporter, err := NewExporter(exportOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c pipelineConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	var tpOpts []sdktrace.TracerProviderOption
+	if c.syncer {
+		tpOpts = append(tpOpts, sdktrace.WithSyncer(exporter))
+	} else {
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter, c.batchOpts...))
+	}
+	if c.resource != nil {
+		tpOpts = append(tpOpts, sdktrace.WithResource(c.resource))
+	}
+	tp := sdktrace.NewTracerProvider(tpOpts...)
+	return tp, tp.Shutdown, nil
+}
+
+// InstallNewTracePipeline creates a trace export pipeline with
+// NewTracePipeline and registers its TracerProvider globally. Contrary to
+// InstallNewPipeline, no metric pipeline is created. It is the
+// responsibility of the caller to call the returned function to flush the
+// spans and shut down the pipeline.
+//
+// Typically this is called as:
+//
+//	tp, shutdown, err := stdout.InstallNewTracePipeline(
+//		[]stdout.Option{stdout.WithPrettyPrint()},
+//		stdout.WithSimpleSpanProcessor(),
+//	)
+//	if err != nil {
+//		...
+//	}
+//	defer shutdown(context.Background())
+func InstallNewTracePipeline(exportOpts []Option, opts ...PipelineOption) (*sdktrace.TracerProvider, func(context.Context) error, error) {
+	tp, shutdown, err := NewTracePipeline(exportOpts, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	otel.SetTracerProvider(tp)
+	return tp, shutdown, nil
+}
@@ -15,18 +15,31 @@
 package stdout_test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -197,3 +210,1528 @@ func TestExporterShutdownNoError(t *testing.T) {
 		t.Errorf("shutdown errored: expected nil, got %v", err)
 	}
 }
+
+// flushWriter is a Writer counting the calls to its Flush method.
+type flushWriter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (w *flushWriter) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func TestExporterShutdownTwice(t *testing.T) {
+	w := &flushWriter{}
+	e, err := stdout.NewExporter(stdout.WithWriter(w))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := e.Shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown %d errored: expected nil, got %v", i, err)
+		}
+	}
+	if w.flushes != 1 {
+		t.Errorf("expected the writer to be flushed once, got %d flushes", w.flushes)
+	}
+
+	// A later call returns immediately, even with a done context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		t.Errorf("shutdown errored: expected nil, got %v", err)
+	}
+}
+
+func TestExporterExportAfterShutdown(t *testing.T) {
+	var b bytes.Buffer
+	e, err := stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown errored: expected nil, got %v", err)
+	}
+
+	ss := []*tracesdk.SpanSnapshot{{Name: "span"}}
+	for i := 0; i < 2; i++ {
+		if err := e.ExportSpans(context.Background(), ss); err == nil {
+			t.Error("expected ErrExporterShutdown error, got nil")
+		} else if !errors.Is(err, stdout.ErrExporterShutdown) {
+			t.Errorf("expected ErrExporterShutdown error, got %v", err)
+		}
+	}
+	if b.Len() != 0 {
+		t.Errorf("expected no output after shutdown, got %q", b.String())
+	}
+}
+
+func TestExporterDedup(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithDedup(2))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	newSpan := func(name string, id byte) *tracesdk.SpanSnapshot {
+		return &tracesdk.SpanSnapshot{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  trace.SpanID{id},
+			}),
+			Name: name,
+		}
+	}
+	exported := func() []string {
+		var got []struct{ Name string }
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode output %q: %v", b.String(), err)
+		}
+		b.Reset()
+		names := make([]string, 0, len(got))
+		for _, s := range got {
+			names = append(names, s.Name)
+		}
+		return names
+	}
+
+	ctx := context.Background()
+	if err := ex.ExportSpans(ctx, []*tracesdk.SpanSnapshot{
+		newSpan("a", 1), newSpan("a", 1), newSpan("b", 2),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"a", "b"}, exported())
+
+	// Both spans are remembered and are skipped.
+	if err := ex.ExportSpans(ctx, []*tracesdk.SpanSnapshot{newSpan("b", 2), newSpan("a", 1)}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, b.String())
+
+	// Exporting a third span evicts the least recently seen span ("b").
+	if err := ex.ExportSpans(ctx, []*tracesdk.SpanSnapshot{newSpan("c", 3), newSpan("b", 2)}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"c", "b"}, exported())
+}
+
+func TestExporterExportDurationAttribute(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	span := &tracesdk.SpanSnapshot{
+		Attributes: []attribute.KeyValue{attribute.Duration("latency", 1500*time.Millisecond)},
+	}
+	if err := ex.ExportSpans(context.Background(), []*tracesdk.SpanSnapshot{span}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, b.String(), `"Attributes":[{"Key":"latency","Value":{"Type":"DURATION","Value":1500000000,"Unit":"ns"}}]`)
+}
+
+func TestExporterExportBytesAttribute(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	span := &tracesdk.SpanSnapshot{
+		Attributes: []attribute.KeyValue{attribute.Bytes("fingerprint", []byte{0xde, 0xad, 0xbe, 0xef})},
+	}
+	if err := ex.ExportSpans(context.Background(), []*tracesdk.SpanSnapshot{span}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, b.String(), `"Attributes":[{"Key":"fingerprint","Value":{"Type":"BYTES","Value":"3q2+7w=="}}]`)
+}
+
+// closableWriter is an io.Writer that records writes made after it has
+// been closed.
+type closableWriter struct {
+	mu           sync.Mutex
+	closed       bool
+	writes       int
+	writesClosed int
+}
+
+func (w *closableWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		w.writesClosed++
+	} else {
+		w.writes++
+	}
+	return len(p), nil
+}
+
+func (w *closableWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+}
+
+func TestExporterConcurrentExportAndShutdown(t *testing.T) {
+	w := &closableWriter{}
+	e, err := stdout.NewExporter(stdout.WithWriter(w))
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	ctx := context.Background()
+	ss := []*tracesdk.SpanSnapshot{{Name: "span"}}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for j := 0; j < 100; j++ {
+				if err := e.ExportSpans(ctx, ss); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	close(start)
+	if err := e.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown errored: expected nil, got %v", err)
+	}
+	w.Close()
+	wg.Wait()
+
+	assert.Zero(t, w.writesClosed, "spans written after shutdown")
+	assert.ErrorIs(t, e.ExportSpans(ctx, ss), stdout.ErrExporterShutdown)
+}
+
+func TestExporterWithoutHTMLEscaping(t *testing.T) {
+	url := attribute.String("http.url", "https://x/?a=1&b=2")
+	ss := []*tracesdk.SpanSnapshot{{
+		Name:       `\u0026 <a>`,
+		Attributes: []attribute.KeyValue{url},
+	}}
+
+	for _, tc := range []struct {
+		name string
+		opts []stdout.Option
+		want string
+	}{
+		{
+			name: "default",
+			want: `{"Key":"http.url","Value":{"Type":"STRING","Value":"https://x/?a=1\u0026b=2"}}`,
+		},
+		{
+			name: "WithoutHTMLEscaping",
+			opts: []stdout.Option{stdout.WithoutHTMLEscaping()},
+			want: `{"Key":"http.url","Value":{"Type":"STRING","Value":"https://x/?a=1&b=2"}}`,
+		},
+		{
+			name: "WithoutHTMLEscaping and WithPrettyPrint",
+			opts: []stdout.Option{stdout.WithoutHTMLEscaping(), stdout.WithPrettyPrint()},
+			want: `"Value": "https://x/?a=1&b=2"`,
+		},
+		{
+			name: "escaped backslash is preserved",
+			opts: []stdout.Option{stdout.WithoutHTMLEscaping()},
+			want: `"Name":"\\u0026 <a>"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			e, err := stdout.NewExporter(append(tc.opts, stdout.WithWriter(&b))...)
+			if err != nil {
+				t.Fatalf("failed to create exporter: %v", err)
+			}
+			if err := e.ExportSpans(context.Background(), ss); err != nil {
+				t.Fatal(err)
+			}
+			assert.Contains(t, b.String(), tc.want)
+		})
+	}
+}
+
+func TestExporterWithSampledField(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ss := []*tracesdk.SpanSnapshot{{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Name: "/foo",
+	}}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithSampledField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[{"SpanContext":{` +
+		`"TraceID":"0102030405060708090a0b0c0d0e0f10",` +
+		`"SpanID":"0102030405060708","TraceFlags":"01","Sampled":true,` +
+		`"TraceState":null,"Remote":false},` +
+		`"Parent":{` +
+		`"TraceID":"00000000000000000000000000000000",` +
+		`"SpanID":"0000000000000000","TraceFlags":"00","Sampled":false,` +
+		`"TraceState":null,"Remote":false},` +
+		`"SpanKind":0,"Name":"/foo",`
+	assert.True(t, strings.HasPrefix(b.String(), want), b.String())
+}
+
+func TestExporterWithOmitEmpty(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ss := []*tracesdk.SpanSnapshot{{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}),
+		SpanKind:   trace.SpanKindServer,
+		Name:       "/foo",
+		Attributes: []attribute.KeyValue{attribute.Int("zero", 0)},
+	}}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithOmitEmpty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	zero, _ := json.Marshal(time.Time{})
+	want := `[{"SpanContext":{` +
+		`"TraceID":"0102030405060708090a0b0c0d0e0f10",` +
+		`"SpanID":"0102030405060708","TraceFlags":"00",` +
+		`"TraceState":null,"Remote":false},` +
+		`"Parent":{` +
+		`"TraceID":"00000000000000000000000000000000",` +
+		`"SpanID":"0000000000000000","TraceFlags":"00",` +
+		`"TraceState":null,"Remote":false},` +
+		`"SpanKind":2,"Name":"/foo",` +
+		`"StartTime":` + string(zero) + `,"EndTime":` + string(zero) + `,` +
+		`"Attributes":[{"Key":"zero","Value":{"Type":"INT64","Value":0}}],` +
+		`"StatusCode":"Unset",` +
+		`"InstrumentationLibrary":{"Name":"","Version":""}}]` + "\n"
+	assert.Equal(t, want, b.String())
+}
+
+func TestExporterWithBatchSequence(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(
+		stdout.WithWriter(&b),
+		stdout.WithBatchSequence(),
+		stdout.WithoutTimestamps(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss := []*tracesdk.SpanSnapshot{{Name: "/foo"}}
+	for i := 0; i < 2; i++ {
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.True(t, strings.HasPrefix(lines[0], `{"batch":1,"spans":[{"SpanContext":`), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], `{"batch":2,"spans":[{"SpanContext":`), lines[1])
+	}
+
+	b.Reset()
+	ex, err = stdout.NewExporter(stdout.WithWriter(&b), stdout.WithBatchSequence())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Batch     uint64
+		Timestamp time.Time
+		Spans     []json.RawMessage
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1), got.Batch)
+	assert.False(t, got.Timestamp.IsZero())
+	assert.Len(t, got.Spans, 1)
+}
+
+func TestExporterWithMaxSpansPerWrite(t *testing.T) {
+	testcases := []struct {
+		max  int
+		want []int
+	}{
+		{max: 0, want: []int{5}},
+		{max: 2, want: []int{2, 2, 1}},
+		{max: 5, want: []int{5}},
+		{max: 10, want: []int{5}},
+	}
+
+	ss := make([]*tracesdk.SpanSnapshot, 5)
+	for i := range ss {
+		ss[i] = &tracesdk.SpanSnapshot{Name: fmt.Sprintf("/foo%d", i)}
+	}
+	for _, tc := range testcases {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithMaxSpansPerWrite(tc.max))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int
+		var names []string
+		dec := json.NewDecoder(&b)
+		for dec.More() {
+			var spans []struct{ Name string }
+			if err := dec.Decode(&spans); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, len(spans))
+			for _, s := range spans {
+				names = append(names, s.Name)
+			}
+		}
+		assert.Equal(t, tc.want, got, "max %d", tc.max)
+		assert.Equal(t, []string{"/foo0", "/foo1", "/foo2", "/foo3", "/foo4"}, names, "max %d", tc.max)
+	}
+}
+
+func TestExporterWithFlattenSlices(t *testing.T) {
+	tags := attribute.Array("tags", []string{"a", "b"})
+	ss := &tracesdk.SpanSnapshot{
+		Name:          "/foo",
+		Attributes:    []attribute.KeyValue{attribute.Int("n", 1), tags},
+		MessageEvents: []tracesdk.Event{{Name: "event", Attributes: []attribute.KeyValue{attribute.Array("ids", []int64{7})}}},
+		Resource:      resource.NewWithAttributes(attribute.Array("hosts", []bool{true, false})),
+	}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithFlattenSlices())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), []*tracesdk.SpanSnapshot{ss}); err != nil {
+		t.Fatal(err)
+	}
+
+	type kv struct {
+		Key   string
+		Value struct {
+			Type  string
+			Value interface{}
+		}
+	}
+	var got []struct {
+		Attributes    []kv
+		MessageEvents []struct{ Attributes []kv }
+		Resource      []kv
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	keys := func(kvs []kv) (out []string) {
+		for _, kv := range kvs {
+			assert.NotEqual(t, "ARRAY", kv.Value.Type, kv.Key)
+			out = append(out, kv.Key)
+		}
+		return out
+	}
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, []string{"n", "tags.0", "tags.1"}, keys(got[0].Attributes))
+		assert.Equal(t, "b", got[0].Attributes[2].Value.Value)
+		if assert.Len(t, got[0].MessageEvents, 1) {
+			assert.Equal(t, []string{"ids.0"}, keys(got[0].MessageEvents[0].Attributes))
+		}
+		assert.Equal(t, []string{"hosts.0", "hosts.1"}, keys(got[0].Resource))
+	}
+
+	// The exported span is not modified.
+	assert.Equal(t, tags, ss.Attributes[1])
+}
+
+type storingHandler struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (h *storingHandler) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errs = append(h.errs, err)
+}
+
+func (h *storingHandler) reset() []error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	errs := h.errs
+	h.errs = nil
+	return errs
+}
+
+var handler = &storingHandler{}
+
+func init() {
+	otel.SetErrorHandler(handler)
+}
+
+func TestExporterWithMaxBytesPerWrite(t *testing.T) {
+	ss := make([]*tracesdk.SpanSnapshot, 5)
+	for i := range ss {
+		ss[i] = &tracesdk.SpanSnapshot{Name: fmt.Sprintf("/foo%d", i)}
+	}
+	export := func(opts ...stdout.Option) []string {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+		return strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	}
+	names := func(lines []string) (out []string) {
+		for _, line := range lines {
+			var spans []struct{ Name string }
+			if err := json.Unmarshal([]byte(line), &spans); err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range spans {
+				out = append(out, s.Name)
+			}
+		}
+		return out
+	}
+	all := []string{"/foo0", "/foo1", "/foo2", "/foo3", "/foo4"}
+
+	whole := export()
+	assert.Len(t, whole, 1)
+	// Size of the array of the first two spans.
+	max := strings.Index(whole[0], `,{"SpanContext"`)
+	max = strings.Index(whole[0][max+1:], `,{"SpanContext"`) + max + 2
+
+	lines := export(stdout.WithMaxBytesPerWrite(max))
+	assert.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), max)
+	}
+	assert.Equal(t, all, names(lines))
+	assert.Empty(t, handler.reset())
+
+	// Spans larger than the maximum are written whole.
+	lines = export(stdout.WithMaxBytesPerWrite(10))
+	assert.Len(t, lines, 5)
+	assert.Equal(t, all, names(lines))
+	assert.Len(t, handler.reset(), 5)
+
+	lines = export(stdout.WithMaxBytesPerWrite(len(whole[0])))
+	assert.Equal(t, whole, lines)
+}
+
+func TestExporterWithLineTerminator(t *testing.T) {
+	ss := []*tracesdk.SpanSnapshot{{Name: "/foo0"}, {Name: "/foo1"}}
+	export := func(opts ...stdout.Option) string {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b), stdout.WithMaxSpansPerWrite(1))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+
+	def := export()
+	assert.Equal(t, 2, strings.Count(def, "\n"))
+	assert.True(t, strings.HasSuffix(def, "]\n"))
+
+	crlf := export(stdout.WithLineTerminator("\r\n"))
+	assert.Equal(t, strings.ReplaceAll(def, "\n", "\r\n"), crlf)
+
+	none := export(stdout.WithLineTerminator(""))
+	assert.Equal(t, strings.ReplaceAll(def, "\n", ""), none)
+
+	for _, invalid := range []string{"x", ";", "\r\n\n", "\n "} {
+		_, err := stdout.NewExporter(stdout.WithLineTerminator(invalid))
+		assert.Errorf(t, err, "%q", invalid)
+	}
+}
+
+func TestExporterWithDryRun(t *testing.T) {
+	ss := []*tracesdk.SpanSnapshot{{Name: "/foo0"}, {Name: "/foo1"}, {Name: "/foo2"}}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithDryRun(), stdout.WithMaxSpansPerWrite(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(0), ex.ExportedCount())
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss[:1]); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(4), ex.ExportedCount())
+	assert.Zero(t, b.Len())
+
+	b.Reset()
+	ex, err = stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(3), ex.ExportedCount())
+	assert.NotZero(t, b.Len())
+}
+
+func TestExporterEncodeSpans(t *testing.T) {
+	now := time.Date(2021, 5, 1, 10, 20, 30, 0, time.UTC)
+	events := []tracesdk.Event{
+		{Name: "second", Time: now.Add(time.Second)},
+		{Name: "<first>", Time: now},
+	}
+	res := resource.NewWithAttributes(attribute.String("service.name", "foo"), attribute.String("host.name", "bar"))
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "/foo0", Attributes: []attribute.KeyValue{attribute.Array("tags", []string{"a", "b"})}},
+		{Name: "/foo1", StartTime: now, EndTime: now.Add(time.Second), MessageEvents: events, Resource: res},
+		{Name: "/foo2", Resource: res},
+	}
+	for _, opts := range [][]stdout.Option{
+		nil,
+		{stdout.WithPrettyPrint()},
+		{stdout.WithOmitEmpty(), stdout.WithSampledField()},
+		{stdout.WithFlattenSlices(), stdout.WithMaxSpansPerWrite(2)},
+		{stdout.WithLineTerminator("\r\n"), stdout.WithoutTimestamps()},
+		{stdout.WithoutHTMLEscaping()},
+		{stdout.WithMaxBytesPerWrite(1200)},
+		{stdout.WithStreaming(), stdout.WithPrettyPrint()},
+		{stdout.WithSortedEvents()},
+		{stdout.WithEventsAsRecords()},
+		{stdout.WithTimestampFormat(time.RFC3339)},
+		{stdout.WithEpochTimestamps()},
+		{stdout.WithUnixNanoTimestamps()},
+		{stdout.WithGroupByResource()},
+		{stdout.WithOTLPShape()},
+		{stdout.WithResourceAttributeFilter(func(kv attribute.KeyValue) bool { return kv.Key == "service.name" })},
+		{stdout.WithOTLPProtobuf()},
+		{stdout.WithOTLPProtobuf(), stdout.WithMaxSpansPerWrite(2)},
+		{stdout.WithOTLPJSON(), stdout.WithPrettyPrint()},
+		{stdout.WithOTLPJSON(), stdout.WithMaxSpansPerWrite(2), stdout.WithLineTerminator("\r\n")},
+	} {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ex.EncodeSpans(ss)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, b.String(), string(got))
+		assert.Equal(t, uint64(len(ss)), ex.ExportedCount())
+	}
+
+	ex, err := stdout.NewExporter(stdout.WithBatchSequence(), stdout.WithoutTimestamps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ex.EncodeSpans(ss[1:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, strings.HasPrefix(string(got), `{"batch":1,`), string(got))
+}
+
+func TestExporterWithSortedEvents(t *testing.T) {
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := []tracesdk.Event{
+		{Name: "third", Time: now.Add(2 * time.Second)},
+		{Name: "first", Time: now},
+		{Name: "second a", Time: now.Add(time.Second)},
+		{Name: "second b", Time: now.Add(time.Second)},
+	}
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "/foo", MessageEvents: events},
+		{Name: "/bar", MessageEvents: events[1:2]},
+	}
+	export := func(opts ...stdout.Option) [][]string {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+		var got []struct {
+			MessageEvents []struct{ Name string }
+		}
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		var names [][]string
+		for _, s := range got {
+			var n []string
+			for _, e := range s.MessageEvents {
+				n = append(n, e.Name)
+			}
+			names = append(names, n)
+		}
+		return names
+	}
+
+	assert.Equal(t, [][]string{{"third", "first", "second a", "second b"}, {"first"}}, export())
+	assert.Equal(t, [][]string{{"first", "second a", "second b", "third"}, {"first"}}, export(stdout.WithSortedEvents()))
+	assert.Equal(t, "third", ss[0].MessageEvents[0].Name, "exported span modified")
+}
+
+func TestExporterWithStreaming(t *testing.T) {
+	ss := []*tracesdk.SpanSnapshot{
+		{
+			Name:       "/foo0",
+			Attributes: []attribute.KeyValue{attribute.String("url", "/a?b=<c>&d")},
+			MessageEvents: []tracesdk.Event{
+				{Name: "event", Attributes: []attribute.KeyValue{attribute.Array("tags", []string{"a", "b"})}},
+			},
+		},
+		{Name: "/foo1"},
+		{Name: "/foo2"},
+	}
+	for _, opts := range [][]stdout.Option{
+		nil,
+		{stdout.WithPrettyPrint()},
+		{stdout.WithoutHTMLEscaping()},
+		{stdout.WithOmitEmpty(), stdout.WithSampledField()},
+		{stdout.WithOmitEmpty(), stdout.WithPrettyPrint()},
+		{stdout.WithFlattenSlices(), stdout.WithMaxSpansPerWrite(2)},
+		{stdout.WithBatchSequence(), stdout.WithoutTimestamps()},
+		{stdout.WithBatchSequence(), stdout.WithoutTimestamps(), stdout.WithPrettyPrint()},
+		{stdout.WithLineTerminator("\r\n")},
+	} {
+		export := func(opts ...stdout.Option) string {
+			var b bytes.Buffer
+			ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ex.ExportSpans(context.Background(), ss); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, uint64(len(ss)), ex.ExportedCount())
+			return b.String()
+		}
+		want := export(opts...)
+		assert.Equal(t, want, export(append(opts, stdout.WithStreaming())...))
+	}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithStreaming(), stdout.WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, b.Len())
+	assert.Equal(t, uint64(len(ss)), ex.ExportedCount())
+
+	_, err = stdout.NewExporter(stdout.WithStreaming(), stdout.WithMaxBytesPerWrite(100))
+	assert.Error(t, err)
+}
+
+// maxWriter discards writes and records the size of the largest one.
+type maxWriter struct {
+	max int
+}
+
+func (w *maxWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		w.max = len(p)
+	}
+	return len(p), nil
+}
+
+// BenchmarkExporterStreaming reports the largest write with B/write, the
+// size of the encoded output held in memory at once.
+func BenchmarkExporterStreaming(b *testing.B) {
+	ss := make([]*tracesdk.SpanSnapshot, 100000)
+	for i := range ss {
+		ss[i] = &tracesdk.SpanSnapshot{
+			Name:       fmt.Sprintf("/foo%d", i),
+			Attributes: []attribute.KeyValue{attribute.Int("index", i)},
+		}
+	}
+	for _, streaming := range []bool{false, true} {
+		name := "Buffered"
+		w := &maxWriter{}
+		opts := []stdout.Option{stdout.WithWriter(w)}
+		if streaming {
+			name = "Streaming"
+			opts = append(opts, stdout.WithStreaming())
+		}
+		b.Run(name, func(b *testing.B) {
+			ex, err := stdout.NewExporter(opts...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := ex.ExportSpans(context.Background(), ss); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(w.max), "B/write")
+		})
+	}
+}
+
+// errWriter is an io.Writer failing every write with err.
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestExporterWithExportResultCallback(t *testing.T) {
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "/foo0"},
+		{Name: "/nan", Attributes: []attribute.KeyValue{attribute.Float64("value", math.NaN())}},
+		{Name: "/foo1"},
+	}
+	for _, streaming := range []bool{false, true} {
+		results := map[string]error{}
+		var b bytes.Buffer
+		opts := []stdout.Option{
+			stdout.WithWriter(&b),
+			stdout.WithExportResultCallback(func(s *tracesdk.SpanSnapshot, err error) {
+				results[s.Name] = err
+			}),
+		}
+		if streaming {
+			opts = append(opts, stdout.WithStreaming())
+		}
+		ex, err := stdout.NewExporter(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Error(t, ex.ExportSpans(context.Background(), ss))
+
+		var got []struct{ Name string }
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []struct{ Name string }{{"/foo0"}, {"/foo1"}}, got, "streaming %t", streaming)
+		if assert.Len(t, results, 3) {
+			assert.NoError(t, results["/foo0"])
+			assert.Error(t, results["/nan"])
+			assert.NoError(t, results["/foo1"])
+		}
+		assert.Equal(t, uint64(2), ex.ExportedCount())
+		assert.Equal(t, "/nan", ss[1].Name, "exported spans modified")
+	}
+
+	// Write errors are reported for every written span.
+	writeErr := errors.New("write failed")
+	var errs []error
+	ex, err := stdout.NewExporter(
+		stdout.WithWriter(errWriter{writeErr}),
+		stdout.WithExportResultCallback(func(_ *tracesdk.SpanSnapshot, err error) {
+			errs = append(errs, err)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, ex.ExportSpans(context.Background(), ss[:1]))
+	assert.Equal(t, []error{writeErr}, errs)
+}
+
+func TestExporterSkipsSpansFailingEncoding(t *testing.T) {
+	nan := attribute.Float64("value", math.NaN())
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "/nan0", Attributes: []attribute.KeyValue{nan}},
+		{Name: "/foo0"},
+		{Name: "/nan1", MessageEvents: []tracesdk.Event{{Name: "event", Attributes: []attribute.KeyValue{nan}}}},
+		{Name: "/foo1"},
+	}
+	for _, opts := range [][]stdout.Option{
+		nil,
+		{stdout.WithStreaming()},
+		{stdout.WithMaxSpansPerWrite(2)},
+		{stdout.WithMaxBytesPerWrite(1 << 20)},
+		{stdout.WithOmitEmpty(), stdout.WithPrettyPrint()},
+	} {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ex.ExportSpans(context.Background(), ss)
+
+		var encErrs stdout.SpanEncodeErrors
+		if assert.True(t, errors.As(err, &encErrs), "%v", err) && assert.Len(t, encErrs, 2) {
+			assert.Equal(t, "/nan0", encErrs[0].Span.Name)
+			assert.Equal(t, "/nan1", encErrs[1].Span.Name)
+			var jsonErr *json.UnsupportedValueError
+			assert.True(t, errors.As(encErrs[0], &jsonErr), "%v", encErrs[0].Err)
+		}
+
+		var names []string
+		dec := json.NewDecoder(&b)
+		for dec.More() {
+			var got []struct{ Name string }
+			if err := dec.Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range got {
+				names = append(names, s.Name)
+			}
+		}
+		assert.Equal(t, []string{"/foo0", "/foo1"}, names)
+		assert.Equal(t, uint64(2), ex.ExportedCount())
+	}
+
+	// Nothing is written if no span can be encoded.
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, ex.ExportSpans(context.Background(), ss[:1]))
+	assert.Zero(t, b.Len())
+}
+
+func TestExporterWithTimestampFormat(t *testing.T) {
+	start := time.Date(2021, 5, 1, 10, 20, 30, 123456789, time.UTC)
+	ss := []*tracesdk.SpanSnapshot{{
+		Name:          "/foo",
+		StartTime:     start,
+		EndTime:       start.Add(time.Second),
+		MessageEvents: []tracesdk.Event{{Name: "event", Time: start.Add(time.Millisecond)}},
+	}}
+	export := func(opts ...stdout.Option) map[string]interface{} {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+		dec := json.NewDecoder(&b)
+		dec.UseNumber()
+		var got []map[string]interface{}
+		if err := dec.Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		return got[0]
+	}
+	eventTime := func(span map[string]interface{}) interface{} {
+		return span["MessageEvents"].([]interface{})[0].(map[string]interface{})["Time"]
+	}
+
+	got := export()
+	assert.Equal(t, "2021-05-01T10:20:30.123456789Z", got["StartTime"])
+	assert.Equal(t, "2021-05-01T10:20:31.123456789Z", got["EndTime"])
+	assert.Equal(t, "2021-05-01T10:20:30.124456789Z", eventTime(got))
+
+	got = export(stdout.WithTimestampFormat(time.Kitchen))
+	assert.Equal(t, "10:20AM", got["StartTime"])
+	assert.Equal(t, "10:20AM", got["EndTime"])
+	assert.Equal(t, "10:20AM", eventTime(got))
+
+	for _, opts := range [][]stdout.Option{
+		{stdout.WithEpochTimestamps()},
+		{stdout.WithEpochTimestamps(), stdout.WithStreaming(), stdout.WithPrettyPrint()},
+		{stdout.WithEpochTimestamps(), stdout.WithSampledField(), stdout.WithOmitEmpty()},
+	} {
+		got = export(opts...)
+		assert.Equal(t, json.Number(fmt.Sprint(start.UnixNano())), got["StartTime"])
+		assert.Equal(t, json.Number(fmt.Sprint(start.Add(time.Second).UnixNano())), got["EndTime"])
+		assert.Equal(t, json.Number(fmt.Sprint(start.Add(time.Millisecond).UnixNano())), eventTime(got))
+	}
+
+	// The last option passed is used.
+	got = export(stdout.WithEpochTimestamps(), stdout.WithTimestampFormat(time.Kitchen))
+	assert.Equal(t, "10:20AM", got["StartTime"])
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithBatchSequence(), stdout.WithEpochTimestamps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now()
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	var batch struct{ Timestamp int64 }
+	if err := json.Unmarshal(b.Bytes(), &batch); err != nil {
+		t.Fatal(err)
+	}
+	assert.GreaterOrEqual(t, batch.Timestamp, before.UnixNano())
+}
+
+func TestExporterWithUnixNanoTimestamps(t *testing.T) {
+	start := time.Date(2021, 5, 1, 10, 20, 30, 123456789, time.FixedZone("CEST", 2*60*60))
+	ss := []*tracesdk.SpanSnapshot{{
+		Name:          "/foo",
+		StartTime:     start,
+		EndTime:       start.Add(1500 * time.Millisecond),
+		MessageEvents: []tracesdk.Event{{Name: "event", Time: start.Add(time.Nanosecond)}},
+	}}
+	for _, opts := range [][]stdout.Option{
+		nil,
+		{stdout.WithStreaming()},
+		{stdout.WithTimestampFormat(time.Kitchen)},
+	} {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b), stdout.WithUnixNanoTimestamps())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []map[string]json.RawMessage
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		span := got[0]
+		assert.NotContains(t, span, "StartTime")
+		assert.NotContains(t, span, "EndTime")
+		assert.Equal(t, fmt.Sprint(ss[0].StartTime.UnixNano()), string(span["StartTimeUnixNano"]))
+		assert.Equal(t, fmt.Sprint(ss[0].EndTime.UnixNano()), string(span["EndTimeUnixNano"]))
+
+		var events []map[string]json.RawMessage
+		if err := json.Unmarshal(span["MessageEvents"], &events); err != nil {
+			t.Fatal(err)
+		}
+		assert.NotContains(t, events[0], "Time")
+		assert.Equal(t, fmt.Sprint(ss[0].MessageEvents[0].Time.UnixNano()), string(events[0]["TimeUnixNano"]))
+	}
+}
+
+func TestExporterWithEventsAsRecords(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	now := time.Date(2021, 5, 1, 10, 20, 30, 0, time.UTC)
+	ss := []*tracesdk.SpanSnapshot{
+		{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+			Name:        "/foo",
+			MessageEvents: []tracesdk.Event{
+				{Name: "first", Time: now, Attributes: []attribute.KeyValue{attribute.String("key", "value")}},
+				{Name: "second", Time: now.Add(time.Second)},
+			},
+		},
+		{Name: "/bar"},
+	}
+	for _, opts := range [][]stdout.Option{nil, {stdout.WithStreaming()}} {
+		var b bytes.Buffer
+		ex, err := stdout.NewExporter(append(opts, stdout.WithWriter(&b), stdout.WithEventsAsRecords())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ex.ExportSpans(context.Background(), ss); err != nil {
+			t.Fatal(err)
+		}
+
+		dec := json.NewDecoder(&b)
+		var spans []map[string]interface{}
+		if err := dec.Decode(&spans); err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, spans, 2)
+		var records []map[string]interface{}
+		for dec.More() {
+			var r map[string]interface{}
+			if err := dec.Decode(&r); err != nil {
+				t.Fatal(err)
+			}
+			records = append(records, r)
+		}
+
+		want := []map[string]interface{}{
+			{
+				"TraceID":  "0102030405060708090a0b0c0d0e0f10",
+				"SpanID":   "0102030405060708",
+				"SpanName": "/foo",
+				"Name":     "first",
+				"Time":     "2021-05-01T10:20:30Z",
+				"Attributes": []interface{}{map[string]interface{}{
+					"Key":   "key",
+					"Value": map[string]interface{}{"Type": "STRING", "Value": "value"},
+				}},
+			},
+			{
+				"TraceID":  "0102030405060708090a0b0c0d0e0f10",
+				"SpanID":   "0102030405060708",
+				"SpanName": "/foo",
+				"Name":     "second",
+				"Time":     "2021-05-01T10:20:31Z",
+			},
+		}
+		assert.Equal(t, want, records)
+	}
+}
+
+func TestEncodeSpansWithEventsAsRecords(t *testing.T) {
+	ex, err := stdout.NewExporter(stdout.WithEventsAsRecords(), stdout.WithUnixNanoTimestamps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2021, 5, 1, 10, 20, 30, 0, time.UTC)
+	ss := []*tracesdk.SpanSnapshot{{Name: "/foo", MessageEvents: []tracesdk.Event{{Name: "event", Time: now}}}}
+	out, err := ex.EncodeSpans(ss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a span and an event record line, got %q", out)
+	}
+	want := fmt.Sprintf(`{"TraceID":"00000000000000000000000000000000","SpanID":"0000000000000000","SpanName":"/foo","Name":"event","TimeUnixNano":%d}`, now.UnixNano())
+	assert.Equal(t, want, lines[1])
+}
+
+func TestExporterWithGroupByResource(t *testing.T) {
+	res1 := resource.NewWithAttributes(attribute.String("service.name", "one"))
+	res2 := resource.NewWithAttributes(attribute.String("service.name", "two"))
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "a", Resource: res1},
+		{Name: "b", Resource: res2},
+		// An equal resource created separately shares the group.
+		{Name: "c", Resource: resource.NewWithAttributes(attribute.String("service.name", "one"))},
+		{Name: "d"},
+	}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithGroupByResource(), stdout.WithOmitEmpty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []struct {
+		Resource []map[string]interface{} `json:"resource"`
+		Spans    []map[string]interface{} `json:"spans"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 resource groups, got %d: %s", len(got), b.String())
+	}
+	names := func(spans []map[string]interface{}) []interface{} {
+		var out []interface{}
+		for _, s := range spans {
+			assert.NotContains(t, s, "Resource")
+			out = append(out, s["Name"])
+		}
+		return out
+	}
+	assert.Equal(t, "one", got[0].Resource[0]["Value"].(map[string]interface{})["Value"])
+	assert.Equal(t, []interface{}{"a", "c"}, names(got[0].Spans))
+	assert.Equal(t, "two", got[1].Resource[0]["Value"].(map[string]interface{})["Value"])
+	assert.Equal(t, []interface{}{"b"}, names(got[1].Spans))
+	assert.Empty(t, got[2].Resource)
+	assert.Equal(t, []interface{}{"d"}, names(got[2].Spans))
+
+	if _, err := stdout.NewExporter(stdout.WithStreaming(), stdout.WithGroupByResource()); err == nil {
+		t.Error("expected an error using streaming with grouping by resource")
+	}
+}
+
+func TestExporterWithOTLPShape(t *testing.T) {
+	res := resource.NewWithAttributes(attribute.String("service.name", "one"))
+	libA := instrumentation.Library{Name: "a", Version: "v1"}
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "1", Resource: res, InstrumentationLibrary: libA},
+		{Name: "2", Resource: res},
+		{Name: "3", Resource: res, InstrumentationLibrary: libA},
+		{Name: "4", InstrumentationLibrary: libA},
+	}
+
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithOTLPShape())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	type scopeSpans struct {
+		Scope map[string]string        `json:"scope"`
+		Spans []map[string]interface{} `json:"spans"`
+	}
+	var got []struct {
+		Resource   []interface{} `json:"resource"`
+		ScopeSpans []scopeSpans  `json:"scopeSpans"`
+		Spans      interface{}   `json:"spans"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 resource groups, got %d: %s", len(got), b.String())
+	}
+	names := func(ss scopeSpans) []interface{} {
+		var out []interface{}
+		for _, s := range ss.Spans {
+			assert.NotContains(t, s, "Resource")
+			assert.NotContains(t, s, "InstrumentationLibrary")
+			out = append(out, s["Name"])
+		}
+		return out
+	}
+
+	assert.Len(t, got[0].Resource, 1)
+	assert.Nil(t, got[0].Spans)
+	if assert.Len(t, got[0].ScopeSpans, 2) {
+		assert.Equal(t, map[string]string{"name": "a", "version": "v1"}, got[0].ScopeSpans[0].Scope)
+		assert.Equal(t, []interface{}{"1", "3"}, names(got[0].ScopeSpans[0]))
+		// The spans without instrumentation library have an empty scope.
+		assert.Equal(t, map[string]string{"name": ""}, got[0].ScopeSpans[1].Scope)
+		assert.Equal(t, []interface{}{"2"}, names(got[0].ScopeSpans[1]))
+	}
+	assert.Empty(t, got[1].Resource)
+	if assert.Len(t, got[1].ScopeSpans, 1) {
+		assert.Equal(t, []interface{}{"4"}, names(got[1].ScopeSpans[0]))
+	}
+}
+
+type failingExporter struct {
+	err      error
+	shutdown bool
+}
+
+func (e *failingExporter) ExportSpans(context.Context, []*tracesdk.SpanSnapshot) error {
+	return e.err
+}
+
+func (e *failingExporter) Shutdown(context.Context) error {
+	e.shutdown = true
+	return e.err
+}
+
+func TestTee(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := tracetest.NewInMemoryExporter()
+	failing := &failingExporter{err: errors.New("failed")}
+	tee := stdout.Tee(ex, nil, failing, mem)
+
+	ss := []*tracesdk.SpanSnapshot{{Name: "span"}}
+	err = tee.ExportSpans(context.Background(), ss)
+	var teeErrs stdout.TeeErrors
+	if !errors.As(err, &teeErrs) {
+		t.Fatalf("expected TeeErrors, got %v", err)
+	}
+	assert.Equal(t, stdout.TeeErrors{failing.err}, teeErrs)
+	assert.Equal(t, "failed", err.Error())
+	assert.Contains(t, b.String(), `"Name":"span"`)
+	assert.Equal(t, ss, mem.GetSpans())
+
+	err = tee.Shutdown(context.Background())
+	assert.Equal(t, stdout.TeeErrors{failing.err}, err)
+	assert.True(t, failing.shutdown)
+	assert.Empty(t, mem.GetSpans())
+}
+
+func TestTeeNoError(t *testing.T) {
+	tee := stdout.Tee(tracetest.NewNoopExporter(), tracetest.NewInMemoryExporter())
+	if err := tee.ExportSpans(context.Background(), []*tracesdk.SpanSnapshot{{Name: "span"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tee.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExporterWithOTLPProtobuf(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithOTLPProtobuf(), stdout.WithMaxSpansPerWrite(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "first", Resource: resource.Empty(), StartTime: time.Unix(0, 1), EndTime: time.Unix(0, 2)},
+		{Name: "second", Resource: resource.Empty(), StartTime: time.Unix(0, 1), EndTime: time.Unix(0, 2)},
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	// The requests hold an empty resource and a span with zero IDs and an
+	// ok status.
+	want := map[string]string{
+		"first": "0a3f" + "0a00" + "123b" + "1239" +
+			"0a10" + "00000000000000000000000000000000" + // Trace ID.
+			"1208" + "0000000000000000" + // Span ID.
+			"2a05" + "6669727374" + // Name.
+			"390100000000000000" + "410200000000000000" + // Start and end times.
+			"7a021801", // Status.
+		"second": "0a40" + "0a00" + "123c" + "123a" +
+			"0a10" + "00000000000000000000000000000000" +
+			"1208" + "0000000000000000" +
+			"2a06" + "7365636f6e64" +
+			"390100000000000000" + "410200000000000000" +
+			"7a021801",
+	}
+	r := bufio.NewReader(&b)
+	for _, s := range ss {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, want[s.Name], hex.EncodeToString(msg), s.Name)
+	}
+	if _, err := r.ReadByte(); err != io.EOF {
+		t.Fatalf("expected the end of the output, got %v", err)
+	}
+
+	for _, opt := range []stdout.Option{stdout.WithStreaming(), stdout.WithMaxBytesPerWrite(1024)} {
+		if _, err := stdout.NewExporter(stdout.WithOTLPProtobuf(), opt); err == nil {
+			t.Errorf("expected an error using OTLP protobuf with %T", opt)
+		}
+	}
+}
+
+func TestExporterWithOTLPJSON(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithOTLPJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ss := []*tracesdk.SpanSnapshot{{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}),
+		Name:                   "span",
+		StartTime:              time.Unix(0, 1),
+		EndTime:                time.Unix(0, 2),
+		Attributes:             []attribute.KeyValue{attribute.String("key", "<value>")},
+		Resource:               resource.Empty(),
+		InstrumentationLibrary: instrumentation.Library{Name: "lib"},
+	}}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"resourceSpans":[{"resource":{},"instrumentationLibrarySpans":[{"instrumentationLibrary":{"name":"lib"},` +
+		`"spans":[{"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"0102030405060708","name":"span",` +
+		`"startTimeUnixNano":"1","endTimeUnixNano":"2","attributes":[{"key":"key","value":{"stringValue":"\u003cvalue\u003e"}}],` +
+		`"status":{"code":1}}]}]}]}` + "\n"
+	assert.Equal(t, want, b.String())
+
+	if _, err := stdout.NewExporter(stdout.WithOTLPJSON(), stdout.WithOTLPProtobuf()); err == nil {
+		t.Error("expected an error using both OTLP JSON and protobuf")
+	}
+	if _, err := stdout.NewExporter(stdout.WithOTLPJSON(), stdout.WithStreaming()); err == nil {
+		t.Error("expected an error using OTLP JSON with streaming")
+	}
+}
+
+// otlpJSONSample is an OTLP/JSON request as in the examples of the OTLP
+// specification.
+const otlpJSONSample = `{
+	"resourceSpans": [{
+		"resource": {"attributes": [{"key": "service.name", "value": {"stringValue": "my.service"}}]},
+		"instrumentationLibrarySpans": [{
+			"instrumentationLibrary": {"name": "my.library", "version": "1.0.0"},
+			"spans": [{
+				"traceId": "5b8efff798038103d269b633813fc60c",
+				"spanId": "eee19b7ec3c1b174",
+				"parentSpanId": "eee19b7ec3c1b173",
+				"name": "I'm a server span",
+				"kind": 2,
+				"startTimeUnixNano": "1544712660000000000",
+				"endTimeUnixNano": "1544712661000000000",
+				"attributes": [
+					{"key": "my.span.attr", "value": {"stringValue": "some value"}},
+					{"key": "my.span.count", "value": {"intValue": "42"}}
+				],
+				"events": [{"timeUnixNano": "1544712660500000000", "name": "event"}],
+				"links": [{"traceId": "5b8efff798038103d269b633813fc60c", "spanId": "eee19b7ec3c1b172"}],
+				"status": {"message": "failed", "code": 2}
+			}]
+		}]
+	}]
+}`
+
+func TestExporterWithOTLPJSONSample(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithOTLPJSON(), stdout.WithPrettyPrint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	traceID, _ := trace.TraceIDFromHex("5b8efff798038103d269b633813fc60c")
+	spanID := func(s string) trace.SpanID {
+		id, _ := trace.SpanIDFromHex(s)
+		return id
+	}
+	start := time.Unix(0, 1544712660000000000)
+	ss := []*tracesdk.SpanSnapshot{{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID("eee19b7ec3c1b174")}),
+		Parent:      trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID("eee19b7ec3c1b173")}),
+		SpanKind:    trace.SpanKindServer,
+		Name:        "I'm a server span",
+		StartTime:   start,
+		EndTime:     start.Add(time.Second),
+		Attributes: []attribute.KeyValue{
+			attribute.String("my.span.attr", "some value"),
+			attribute.Int("my.span.count", 42),
+		},
+		MessageEvents: []tracesdk.Event{{Name: "event", Time: start.Add(500 * time.Millisecond)}},
+		Links: []trace.Link{
+			{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID("eee19b7ec3c1b172")})},
+		},
+		StatusCode:             codes.Error,
+		StatusMessage:          "failed",
+		Resource:               resource.NewWithAttributes(attribute.String("service.name", "my.service")),
+		InstrumentationLibrary: instrumentation.Library{Name: "my.library", Version: "1.0.0"},
+	}}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, otlpJSONSample, b.String())
+}
+
+func TestNewTracePipeline(t *testing.T) {
+	var b bytes.Buffer
+	res := resource.NewWithAttributes(attribute.String("service.name", "pipeline"))
+	tp, shutdown, err := stdout.NewTracePipeline(
+		[]stdout.Option{stdout.WithWriter(&b), stdout.WithoutTimestamps()},
+		stdout.WithSimpleSpanProcessor(),
+		stdout.WithResource(res),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := tp.Tracer("pipeline").Start(context.Background(), "span")
+	span.End()
+	// The span is written synchronously.
+	assert.Contains(t, b.String(), `"Name":"span"`)
+	assert.Contains(t, b.String(), `"Value":"pipeline"`)
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, span = tp.Tracer("pipeline").Start(context.Background(), "after shutdown")
+	span.End()
+	assert.NotContains(t, b.String(), "after shutdown")
+}
+
+func TestInstallNewTracePipeline(t *testing.T) {
+	orig := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(orig)
+
+	var b bytes.Buffer
+	tp, shutdown, err := stdout.InstallNewTracePipeline([]stdout.Option{stdout.WithWriter(&b)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Same(t, tp, otel.GetTracerProvider())
+
+	_, span := otel.Tracer("pipeline").Start(context.Background(), "span")
+	span.End()
+	// The batched span is written once shut down.
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, b.String(), `"Name":"span"`)
+
+	if _, _, err := stdout.InstallNewTracePipeline([]stdout.Option{stdout.WithStreaming(), stdout.WithMaxBytesPerWrite(1)}); err == nil {
+		t.Error("expected an error with an invalid exporter configuration")
+	}
+}
+
+func TestExporterWithResourceAttributeFilter(t *testing.T) {
+	var b bytes.Buffer
+	keep := func(kv attribute.KeyValue) bool { return !strings.HasPrefix(string(kv.Key), "host.") }
+	ex, err := stdout.NewExporter(stdout.WithWriter(&b), stdout.WithResourceAttributeFilter(keep))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := resource.NewWithAttributes(
+		attribute.String("service.name", "filtered"),
+		attribute.String("host.name", "secret"),
+		attribute.String("host.ip", "10.0.0.1"),
+	)
+	ss := []*tracesdk.SpanSnapshot{
+		{Name: "a", Resource: res},
+		{Name: "b", Resource: res},
+		{Name: "c"},
+	}
+	if err := ex.ExportSpans(context.Background(), ss); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []struct {
+		Name     string
+		Resource []struct{ Key string }
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d spans, want 3", len(got))
+	}
+	for _, s := range got[:2] {
+		if len(s.Resource) != 1 || s.Resource[0].Key != "service.name" {
+			t.Errorf("span %s: got resource %v, want only service.name", s.Name, s.Resource)
+		}
+	}
+	assert.Empty(t, got[2].Resource)
+	// The exported spans are not modified.
+	assert.Equal(t, 3, ss[0].Resource.Len())
+}
@@ -232,7 +232,7 @@ func spanSnapshotToThrift(ss *sdktrace.SpanSnapshot) *gen.Span {
 		SpanId:        int64(binary.BigEndian.Uint64(sid[:])),
 		ParentSpanId:  int64(binary.BigEndian.Uint64(psid[:])),
 		OperationName: ss.Name, // TODO: if span kind is added then add prefix "Sent"/"Recv"
-		Flags:         int32(ss.SpanContext.TraceFlags()),
+		Flags:         jaegerFlags(ss.SpanContext.TraceFlags()),
 		StartTime:     ss.StartTime.UnixNano() / 1000,
 		Duration:      ss.EndTime.Sub(ss.StartTime).Nanoseconds() / 1000,
 		Tags:          tags,
@@ -241,6 +241,26 @@ func spanSnapshotToThrift(ss *sdktrace.SpanSnapshot) *gen.Span {
 	}
 }
 
+// The flags of Jaeger spans.
+const (
+	jaegerFlagSampled = 0x01
+	jaegerFlagDebug   = 0x02
+)
+
+// jaegerFlags returns the Jaeger span flags of tf. The FlagsDebug bit does
+// not have the value of the Jaeger debug flag, and the other bits, e.g. the
+// Jaeger firehose flag, have no TraceFlags equivalent and are not set.
+func jaegerFlags(tf trace.TraceFlags) int32 {
+	var flags int32
+	if tf.IsSampled() {
+		flags |= jaegerFlagSampled
+	}
+	if tf.IsDebug() {
+		flags |= jaegerFlagDebug
+	}
+	return flags
+}
+
 func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 	var tag *gen.Tag
 	switch keyValue.Value.Type() {
@@ -265,6 +285,13 @@ func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 			VLong: &i,
 			VType: gen.TagType_LONG,
 		}
+	case attribute.DURATION:
+		i := int64(keyValue.Value.AsDuration())
+		tag = &gen.Tag{
+			Key:   string(keyValue.Key),
+			VLong: &i,
+			VType: gen.TagType_LONG,
+		}
 	case attribute.FLOAT64:
 		f := keyValue.Value.AsFloat64()
 		tag = &gen.Tag{
@@ -272,6 +299,12 @@ func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 			VDouble: &f,
 			VType:   gen.TagType_DOUBLE,
 		}
+	case attribute.BYTES:
+		tag = &gen.Tag{
+			Key:     string(keyValue.Key),
+			VBinary: keyValue.Value.AsBytes(),
+			VType:   gen.TagType_BINARY,
+		}
 	case attribute.ARRAY:
 		json, _ := json.Marshal(keyValue.Value.AsArray())
 		a := (string)(json)
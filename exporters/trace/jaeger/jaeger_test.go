@@ -460,6 +460,27 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 	}
 }
 
+func TestJaegerFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		flags trace.TraceFlags
+		want  int32
+	}{
+		{name: "none", flags: 0, want: 0},
+		{name: "sampled", flags: trace.FlagsSampled, want: 0x01},
+		{name: "debug", flags: trace.FlagsDebug, want: 0x02},
+		{name: "sampled and debug", flags: trace.FlagsSampled | trace.FlagsDebug, want: 0x03},
+		{name: "unknown bits", flags: trace.TraceFlags(0xfa), want: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := &sdktrace.SpanSnapshot{
+				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceFlags: tt.flags}),
+			}
+			assert.Equal(t, tt.want, spanSnapshotToThrift(ss).Flags)
+		})
+	}
+}
+
 func TestExporterShutdownHonorsCancel(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -19,6 +19,8 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
+
+	"go.opentelemetry.io/otel/internal"
 )
 
 var (
@@ -38,6 +40,12 @@ var (
 	_ ErrorHandler = (*loggingErrorHandler)(nil)
 )
 
+func init() {
+	// Let the packages that cannot import this one report their errors to
+	// the global ErrorHandler.
+	internal.Handle = Handle
+}
+
 // loggingErrorHandler logs all errors to STDERR.
 type loggingErrorHandler struct {
 	delegate atomic.Value
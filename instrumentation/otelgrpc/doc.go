@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelgrpc provides gRPC interceptors tracing the RPCs of clients
+// and servers.
+//
+// The interceptors start a span for every RPC named after the full method
+// of the RPC, e.g. "/package.Service/Method", with the rpc.* semantic
+// convention attributes. The span context is propagated in the gRPC
+// metadata of the RPC and the status of the span is set from the gRPC
+// status code of the RPC.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package otelgrpc // import "go.opentelemetry.io/otel/instrumentation/otelgrpc"
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc // import "go.opentelemetry.io/otel/instrumentation/otelgrpc"
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor tracing the
+// unary RPCs of a client.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts)
+	tracer := c.tracer()
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, span := tracer.Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(rpcAttributes(method)...),
+		)
+		defer span.End()
+
+		err := invoker(inject(ctx, c.propagators), method, req, reply, cc, callOpts...)
+		setStatus(span, err, trace.SpanKindClient)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor tracing the
+// streaming RPCs of a client.
+//
+// The span of an RPC is ended when the stream is finished, i.e. when RecvMsg
+// returns an error, or the response for RPCs without a server stream.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts)
+	tracer := c.tracer()
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := tracer.Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(rpcAttributes(method)...),
+		)
+
+		s, err := streamer(inject(ctx, c.propagators), desc, cc, method, callOpts...)
+		if err != nil {
+			setStatus(span, err, trace.SpanKindClient)
+			span.End()
+			return s, err
+		}
+		return &clientStream{ClientStream: s, span: span, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor tracing the
+// unary RPCs handled by a server.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+	tracer := c.tracer()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := tracer.Start(extract(ctx, c.propagators), info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		setStatus(span, err, trace.SpanKindServer)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor tracing the
+// streaming RPCs handled by a server.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+	tracer := c.tracer()
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := tracer.Start(extract(ss.Context(), c.propagators), info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		setStatus(span, err, trace.SpanKindServer)
+		return err
+	}
+}
+
+// clientStream ends the span of a streaming RPC when the stream is finished.
+type clientStream struct {
+	grpc.ClientStream
+
+	span          trace.Span
+	serverStreams bool
+	once          sync.Once
+}
+
+func (s *clientStream) end(err error) {
+	s.once.Do(func() {
+		setStatus(s.span, err, trace.SpanKindClient)
+		s.span.End()
+	})
+}
+
+func (s *clientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.end(err)
+	}
+	return md, err
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	// On io.EOF the status of the RPC is returned by RecvMsg.
+	if err != nil && err != io.EOF {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *clientStream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.end(nil)
+	case err != nil:
+		s.end(err)
+	case !s.serverStreams:
+		s.end(nil)
+	}
+	return err
+}
+
+// serverStream overrides the context of a grpc.ServerStream with the one
+// containing the span of the RPC.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// inject returns a copy of ctx with the outgoing gRPC metadata containing
+// the span context of ctx.
+func inject(ctx context.Context, p propagation.TextMapPropagator) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	p.Inject(ctx, propagation.MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// extract returns a copy of ctx containing the span context propagated in
+// the incoming gRPC metadata of ctx.
+func extract(ctx context.Context, p propagation.TextMapPropagator) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return p.Extract(ctx, propagation.MetadataCarrier(md))
+}
+
+// rpcAttributes returns the semantic convention attributes of the RPC of the
+// full method name, e.g. "/package.Service/Method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		if service := name[:i]; service != "" {
+			attrs = append(attrs, semconv.RPCServiceKey.String(service))
+		}
+		if method := name[i+1:]; method != "" {
+			attrs = append(attrs, semconv.RPCMethodKey.String(method))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const fullMethod = "/grpc.testing.TestService/UnaryCall"
+
+func testOptions(sr *oteltest.SpanRecorder) []Option {
+	return []Option{
+		WithTracerProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(sr))),
+		WithPropagators(propagation.TraceContext{}),
+	}
+}
+
+func assertRPCSpan(t *testing.T, span *oteltest.Span, kind trace.SpanKind, code grpccodes.Code) {
+	t.Helper()
+	assert.Equal(t, fullMethod, span.Name())
+	assert.Equal(t, kind, span.SpanKind())
+	assert.True(t, span.Ended())
+	attrs := span.Attributes()
+	assert.Equal(t, semconv.RPCSystemGRPC.Value, attrs[semconv.RPCSystemKey])
+	assert.Equal(t, attribute.StringValue("grpc.testing.TestService"), attrs[semconv.RPCServiceKey])
+	assert.Equal(t, attribute.StringValue("UnaryCall"), attrs[semconv.RPCMethodKey])
+	assert.Equal(t, attribute.IntValue(int(code)), attrs[GRPCStatusCodeKey])
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	sr := new(oteltest.SpanRecorder)
+	interceptor := UnaryClientInterceptor(testOptions(sr)...)
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return status.Error(grpccodes.NotFound, "not found")
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "key", "value")
+	err := interceptor(ctx, fullMethod, nil, nil, nil, invoker)
+	assert.Equal(t, grpccodes.NotFound, status.Code(err))
+
+	spans := sr.Completed()
+	require.Len(t, spans, 1)
+	assertRPCSpan(t, spans[0], trace.SpanKindClient, grpccodes.NotFound)
+	assert.Equal(t, codes.Error, spans[0].StatusCode())
+	assert.Equal(t, "not found", spans[0].StatusMessage())
+
+	assert.Equal(t, []string{"value"}, md.Get("key"))
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), propagation.MetadataCarrier(md)))
+	assert.Equal(t, spans[0].SpanContext().SpanID(), sc.SpanID())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	sr := new(oteltest.SpanRecorder)
+	interceptor := UnaryServerInterceptor(testOptions(sr)...)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	md := metadata.MD{}
+	propagation.TraceContext{}.Inject(trace.ContextWithRemoteSpanContext(context.Background(), parent), propagation.MetadataCarrier(md))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	var handlerSC trace.SpanContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerSC = trace.SpanContextFromContext(ctx)
+		return "resp", nil
+	}
+	resp, err := interceptor(ctx, "req", &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+
+	spans := sr.Completed()
+	require.Len(t, spans, 1)
+	assertRPCSpan(t, spans[0], trace.SpanKindServer, grpccodes.OK)
+	assert.Equal(t, codes.Unset, spans[0].StatusCode())
+	assert.Equal(t, parent.TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, parent.SpanID(), spans[0].ParentSpanID())
+	assert.Equal(t, spans[0].SpanContext(), handlerSC)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	sr := new(oteltest.SpanRecorder)
+	interceptor := StreamServerInterceptor(testOptions(sr)...)
+
+	var handlerSC trace.SpanContext
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handlerSC = trace.SpanContextFromContext(ss.Context())
+		return status.Error(grpccodes.Internal, "internal")
+	}
+	ss := testServerStream{ctx: context.Background()}
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: fullMethod}, handler)
+	assert.Equal(t, grpccodes.Internal, status.Code(err))
+
+	spans := sr.Completed()
+	require.Len(t, spans, 1)
+	assertRPCSpan(t, spans[0], trace.SpanKindServer, grpccodes.Internal)
+	assert.Equal(t, codes.Error, spans[0].StatusCode())
+	assert.Equal(t, spans[0].SpanContext(), handlerSC)
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+	recv []error
+}
+
+func (s *testClientStream) RecvMsg(interface{}) error {
+	err := s.recv[0]
+	s.recv = s.recv[1:]
+	return err
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	testcases := []struct {
+		name          string
+		serverStreams bool
+		recv          []error
+		code          grpccodes.Code
+	}{
+		{
+			name:          "server stream",
+			serverStreams: true,
+			recv:          []error{nil, nil, io.EOF},
+			code:          grpccodes.OK,
+		},
+		{
+			name: "client stream",
+			recv: []error{nil},
+			code: grpccodes.OK,
+		},
+		{
+			name:          "error",
+			serverStreams: true,
+			recv:          []error{nil, status.Error(grpccodes.Unavailable, "unavailable")},
+			code:          grpccodes.Unavailable,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := new(oteltest.SpanRecorder)
+			interceptor := StreamClientInterceptor(testOptions(sr)...)
+
+			var md metadata.MD
+			streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return &testClientStream{recv: tc.recv}, nil
+			}
+			desc := &grpc.StreamDesc{ServerStreams: tc.serverStreams, ClientStreams: true}
+			s, err := interceptor(context.Background(), desc, nil, fullMethod, streamer)
+			require.NoError(t, err)
+			assert.NotEmpty(t, md.Get("traceparent"))
+
+			n := len(tc.recv)
+			for i := 0; i < n; i++ {
+				assert.Empty(t, sr.Completed(), "span ended before the stream finished")
+				_ = s.RecvMsg(nil)
+			}
+
+			spans := sr.Completed()
+			require.Len(t, spans, 1)
+			assertRPCSpan(t, spans[0], trace.SpanKindClient, tc.code)
+		})
+	}
+}
+
+func TestStreamClientInterceptorStreamerError(t *testing.T) {
+	sr := new(oteltest.SpanRecorder)
+	interceptor := StreamClientInterceptor(testOptions(sr)...)
+
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, errors.New("dial failed")
+	}
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, fullMethod, streamer)
+	assert.Error(t, err)
+
+	spans := sr.Completed()
+	require.Len(t, spans, 1)
+	assertRPCSpan(t, spans[0], trace.SpanKindClient, grpccodes.Unknown)
+	assert.Equal(t, codes.Error, spans[0].StatusCode())
+}
+
+func TestSpanStatusCode(t *testing.T) {
+	serverErrors := map[grpccodes.Code]bool{
+		grpccodes.Unknown:          true,
+		grpccodes.DeadlineExceeded: true,
+		grpccodes.Unimplemented:    true,
+		grpccodes.Internal:         true,
+		grpccodes.Unavailable:      true,
+		grpccodes.DataLoss:         true,
+	}
+	for c := grpccodes.OK; c <= grpccodes.Unauthenticated; c++ {
+		wantClient, wantServer := codes.Error, codes.Unset
+		if c == grpccodes.OK {
+			wantClient = codes.Unset
+		}
+		if serverErrors[c] {
+			wantServer = codes.Error
+		}
+		assert.Equal(t, wantClient, spanStatusCode(c, trace.SpanKindClient), "client %s", c)
+		assert.Equal(t, wantServer, spanStatusCode(c, trace.SpanKindServer), "server %s", c)
+	}
+}
+
+func TestRPCAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCServiceKey.String("package.Service"),
+		semconv.RPCMethodKey.String("Method"),
+	}, rpcAttributes("/package.Service/Method"))
+	assert.Equal(t, []attribute.KeyValue{semconv.RPCSystemGRPC}, rpcAttributes("invalid"))
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc // import "go.opentelemetry.io/otel/instrumentation/otelgrpc"
+
+import (
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GRPCStatusCodeKey is the attribute key of the numeric gRPC status code of
+// an RPC.
+const GRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+
+// spanStatusCode returns the span status code of an RPC with the gRPC status
+// code c for a span of kind.
+//
+// Clients consider every status other than OK an error. Servers only
+// consider the statuses indicating a fault of the server an error, all
+// other statuses are the result of the requests of the clients.
+func spanStatusCode(c grpccodes.Code, kind trace.SpanKind) codes.Code {
+	if c == grpccodes.OK {
+		return codes.Unset
+	}
+	if kind != trace.SpanKindServer {
+		return codes.Error
+	}
+	switch c {
+	case grpccodes.Unknown,
+		grpccodes.DeadlineExceeded,
+		grpccodes.Unimplemented,
+		grpccodes.Internal,
+		grpccodes.Unavailable,
+		grpccodes.DataLoss:
+		return codes.Error
+	default:
+		return codes.Unset
+	}
+}
+
+// setStatus sets the gRPC status code attribute and the status of span
+// based on the error err returned by the RPC.
+func setStatus(span trace.Span, err error, kind trace.SpanKind) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(GRPCStatusCodeKey.Int(int(s.Code())))
+	if c := spanStatusCode(s.Code(), kind); c != codes.Unset {
+		span.SetStatus(c, s.Message())
+	}
+}
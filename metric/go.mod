@@ -22,6 +22,8 @@ replace go.opentelemetry.io/otel/example/prometheus => ../example/prometheus
 
 replace go.opentelemetry.io/otel/example/zipkin => ../example/zipkin
 
+replace go.opentelemetry.io/otel/exporters/autoexport => ../exporters/autoexport
+
 replace go.opentelemetry.io/otel/exporters/metric/prometheus => ../exporters/metric/prometheus
 
 replace go.opentelemetry.io/otel/exporters/otlp => ../exporters/otlp
@@ -34,6 +36,8 @@ replace go.opentelemetry.io/otel/exporters/trace/zipkin => ../exporters/trace/zi
 
 replace go.opentelemetry.io/otel/internal/tools => ../internal/tools
 
+replace go.opentelemetry.io/otel/instrumentation/otelgrpc => ../instrumentation/otelgrpc
+
 replace go.opentelemetry.io/otel/metric => ./
 
 replace go.opentelemetry.io/otel/oteltest => ../oteltest
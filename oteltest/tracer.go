@@ -76,6 +76,9 @@ func (t *Tracer) Start(ctx context.Context, name string, opts ...trace.SpanOptio
 
 	span.SetName(name)
 	span.SetAttributes(c.Attributes...)
+	for _, e := range c.StartEvents {
+		span.AddEvent(e.Name, trace.WithTimestamp(startTime), trace.WithAttributes(e.Attributes...))
+	}
 
 	if t.config.SpanRecorder != nil {
 		t.config.SpanRecorder.OnStart(span)
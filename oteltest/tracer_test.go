@@ -62,6 +62,32 @@ func TestTracer(t *testing.T) {
 			e.Expect(testSpan.StartTime()).ToEqual(expectedStartTime)
 		})
 
+		t.Run("adds the events from WithStartEvent", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+
+			startTime := time.Now().AddDate(5, 0, 0)
+			attr := attribute.String("a", "1")
+
+			subject := tp.Tracer(t.Name())
+			_, span := subject.Start(
+				context.Background(),
+				"test",
+				trace.WithTimestamp(startTime),
+				trace.WithStartEvent("start", attr),
+			)
+
+			testSpan, ok := span.(*oteltest.Span)
+			e.Expect(ok).ToBeTrue()
+
+			e.Expect(testSpan.Events()).ToEqual([]oteltest.Event{{
+				Timestamp:  startTime,
+				Name:       "start",
+				Attributes: map[attribute.Key]attribute.Value{attr.Key: attr.Value},
+			}})
+		})
+
 		t.Run("uses the attributes from WithAttributes", func(t *testing.T) {
 			t.Parallel()
 
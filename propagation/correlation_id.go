@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"strings"
+)
+
+type correlationID struct {
+	header string
+	key    interface{}
+}
+
+var _ TextMapPropagator = correlationID{}
+
+// CorrelationID returns a TextMapPropagator that propagates a single
+// correlation ID, like the X-Request-ID header of legacy systems, stored in
+// the header named headerName and as the string value of ctxKey in the
+// context. It is independent of the trace context and can be combined with
+// other propagators with NewCompositeTextMapPropagator, the correlation ID
+// read from the context can then be recorded on spans.
+//
+// As with any context key, ctxKey should be of a type defined by the caller
+// to avoid collisions.
+func CorrelationID(headerName string, ctxKey interface{}) TextMapPropagator {
+	return correlationID{header: headerName, key: ctxKey}
+}
+
+// Inject sets the correlation ID of ctx into carrier, if ctx has a non-empty
+// one.
+func (p correlationID) Inject(ctx context.Context, carrier TextMapCarrier) {
+	if id, _ := ctx.Value(p.key).(string); id != "" {
+		carrier.Set(p.header, id)
+	}
+}
+
+// Extract returns a copy of parent with the correlation ID of carrier, if
+// carrier has a non-empty one, or parent otherwise.
+func (p correlationID) Extract(parent context.Context, carrier TextMapCarrier) context.Context {
+	id := strings.TrimSpace(carrier.Get(p.header))
+	if id == "" {
+		return parent
+	}
+	return context.WithValue(parent, p.key, id)
+}
+
+// Fields returns the name of the correlation ID header.
+func (p correlationID) Fields() []string {
+	return []string{p.header}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+func TestCorrelationIDExtract(t *testing.T) {
+	prop := propagation.CorrelationID("X-Request-ID", requestIDKey{})
+	carrier := propagation.HeaderCarrier(http.Header{})
+	carrier.Set("X-Request-ID", " 4f2c1e ")
+	ctx := prop.Extract(context.Background(), carrier)
+	assert.Equal(t, "4f2c1e", ctx.Value(requestIDKey{}))
+
+	parent := context.Background()
+	assert.Equal(t, parent, prop.Extract(parent, propagation.HeaderCarrier(http.Header{})))
+}
+
+func TestCorrelationIDInject(t *testing.T) {
+	prop := propagation.CorrelationID("X-Request-ID", requestIDKey{})
+	carrier := propagation.HeaderCarrier(http.Header{})
+	prop.Inject(context.WithValue(context.Background(), requestIDKey{}, "4f2c1e"), carrier)
+	assert.Equal(t, "4f2c1e", carrier.Get("X-Request-ID"))
+
+	carrier = propagation.HeaderCarrier(http.Header{})
+	prop.Inject(context.Background(), carrier)
+	assert.Empty(t, carrier.Keys())
+	assert.Equal(t, []string{"X-Request-ID"}, prop.Fields())
+}
+
+func TestCorrelationIDComposite(t *testing.T) {
+	prop := propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.CorrelationID("X-Request-ID", requestIDKey{}),
+	)
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "X-Request-ID"}, prop.Fields())
+
+	carrier := propagation.HeaderCarrier(http.Header{})
+	carrier.Set("traceparent", filterTraceParent)
+	carrier.Set("X-Request-ID", "4f2c1e")
+	ctx := prop.Extract(context.Background(), carrier)
+	assert.Equal(t, "4f2c1e", ctx.Value(requestIDKey{}))
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+
+	out := propagation.HeaderCarrier(http.Header{})
+	prop.Inject(ctx, out)
+	assert.Equal(t, filterTraceParent, out.Get("traceparent"))
+	assert.Equal(t, "4f2c1e", out.Get("X-Request-ID"))
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultAllowedVendors are the tracestate vendors allowed by
+// DefaultAllowKey: OpenTelemetry, Datadog, and Elastic.
+var defaultAllowedVendors = []string{"ot", "dd", "es"}
+
+// DefaultAllowKey allows the tracestate keys of well-known tracing vendors,
+// the "ot" key of OpenTelemetry, the "dd" key of Datadog, and the "es" key of
+// Elastic, as well as their multi-tenant form, e.g. "tenant@dd".
+func DefaultAllowKey(key string) bool {
+	vendor := key
+	if i := strings.LastIndexByte(key, '@'); i >= 0 {
+		vendor = key[i+1:]
+	}
+	for _, v := range defaultAllowedVendors {
+		if vendor == v {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowKeyPrefixes returns a function allowing the tracestate keys starting
+// with one of prefixes to be used with FilterKeys.
+func AllowKeyPrefixes(prefixes ...string) func(key string) bool {
+	p := append([]string(nil), prefixes...)
+	return func(key string) bool {
+		for _, prefix := range p {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type filterKeys struct {
+	inner TextMapPropagator
+	allow func(key string) bool
+}
+
+var _ TextMapPropagator = filterKeys{}
+
+// FilterKeys returns a TextMapPropagator that removes the tracestate members
+// with a key not allowed by allow from the SpanContext injected and extracted
+// by inner. This sanitizes the tracestate received from, and sent to,
+// untrusted parties. If allow is nil, DefaultAllowKey is used.
+//
+// The returned TextMapPropagator has the same Fields as inner.
+func FilterKeys(inner TextMapPropagator, allow func(key string) bool) TextMapPropagator {
+	if allow == nil {
+		allow = DefaultAllowKey
+	}
+	return filterKeys{inner: inner, allow: allow}
+}
+
+// Inject injects the SpanContext of ctx with its tracestate filtered, and
+// anything else set by inner, into carrier.
+func (p filterKeys) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if ts, ok := p.filter(sc.TraceState()); ok {
+		ctx = trace.ContextWithSpanContext(ctx, sc.WithTraceState(ts))
+	}
+	p.inner.Inject(ctx, carrier)
+}
+
+// Extract extracts from carrier with inner and filters the tracestate of
+// the extracted remote SpanContext.
+func (p filterKeys) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	before := trace.SpanContextFromContext(ctx)
+	ctx = p.inner.Extract(ctx, carrier)
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsRemote() || sc.Equal(before) {
+		return ctx
+	}
+	if ts, ok := p.filter(sc.TraceState()); ok {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, sc.WithTraceState(ts))
+	}
+	return ctx
+}
+
+// Fields returns the Fields of the wrapped TextMapPropagator.
+func (p filterKeys) Fields() []string {
+	return p.inner.Fields()
+}
+
+// filter returns ts without the members not allowed, and if any member was
+// removed.
+func (p filterKeys) filter(ts trace.TraceState) (trace.TraceState, bool) {
+	if ts.IsEmpty() {
+		return ts, false
+	}
+	members := strings.Split(ts.String(), ",")
+	kept := members[:0]
+	for _, m := range members {
+		if i := strings.IndexByte(m, '='); i > 0 && p.allow(m[:i]) {
+			kept = append(kept, m)
+		}
+	}
+	if len(kept) == len(members) {
+		return ts, false
+	}
+	return parseTraceState(strings.Join(kept, ",")), true
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const filterTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func filterSpanContext(t *testing.T, kvs ...attribute.KeyValue) trace.SpanContext {
+	ts, err := trace.TraceStateFromKeyValues(kvs...)
+	require.NoError(t, err)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+}
+
+func TestFilterKeysExtract(t *testing.T) {
+	prop := propagation.FilterKeys(propagation.TraceContext{}, nil)
+	carrier := propagation.HeaderCarrier{}
+	carrier.Set("traceparent", filterTraceParent)
+	carrier.Set("tracestate", "rojo=00f067aa0ba902b7,dd=s:1,t1@ot=p:8,congo=t61rcWkgMzE,es=s:1")
+	sc := trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "dd=s:1,t1@ot=p:8,es=s:1", sc.TraceState().String())
+
+	carrier.Set("tracestate", "rojo=00f067aa0ba902b7")
+	sc = trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.TraceState().IsEmpty())
+}
+
+func TestFilterKeysExtractKeepsExistingSpanContext(t *testing.T) {
+	prop := propagation.FilterKeys(propagation.TraceContext{}, propagation.AllowKeyPrefixes("ot"))
+	want := filterSpanContext(t, attribute.String("rojo", "1"))
+	ctx := trace.ContextWithSpanContext(context.Background(), want)
+
+	ctx = prop.Extract(ctx, propagation.HeaderCarrier{})
+	assert.Equal(t, want, trace.SpanContextFromContext(ctx))
+}
+
+func TestFilterKeysInject(t *testing.T) {
+	prop := propagation.FilterKeys(propagation.TraceContext{}, propagation.AllowKeyPrefixes("ve", "ot"))
+	sc := filterSpanContext(t,
+		attribute.String("rojo", "1"),
+		attribute.String("vendor1", "2"),
+		attribute.String("congo", "3"),
+		attribute.String("ot", "4"),
+	)
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	carrier := propagation.HeaderCarrier{}
+	prop.Inject(ctx, carrier)
+	assert.Equal(t, "vendor1=2,ot=4", carrier.Get("tracestate"))
+	assert.Equal(t, "00-01000000000000000000000000000000-0100000000000000-01", carrier.Get("traceparent"))
+
+	// The tracestate of ctx itself is not changed.
+	assert.Equal(t, sc, trace.SpanContextFromContext(ctx))
+}
+
+func TestFilterKeysFields(t *testing.T) {
+	inner := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	assert.ElementsMatch(t, inner.Fields(), propagation.FilterKeys(inner, nil).Fields())
+}
+
+func TestDefaultAllowKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"ot":       true,
+		"dd":       true,
+		"es":       true,
+		"t1@dd":    true,
+		"other":    false,
+		"rojo":     false,
+		"dd@rojo":  false,
+		"ot-extra": false,
+	} {
+		assert.Equal(t, want, propagation.DefaultAllowKey(key), key)
+	}
+}
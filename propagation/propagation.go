@@ -17,6 +17,7 @@ package propagation // import "go.opentelemetry.io/otel/propagation"
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 // TextMapCarrier is the storage medium used by a TextMapPropagator.
@@ -51,6 +52,37 @@ func (hc HeaderCarrier) Keys() []string {
 	return keys
 }
 
+// MetadataCarrier adapts gRPC metadata to satisfy the TextMapCarrier
+// interface. It has the same underlying type as metadata.MD of the
+// google.golang.org/grpc/metadata package, a metadata.MD md can be used as a
+// carrier with MetadataCarrier(md).
+//
+// Like metadata.MD, keys are lowercased when they are read or stored.
+type MetadataCarrier map[string][]string
+
+// Get returns the first value associated with the passed key.
+func (mc MetadataCarrier) Get(key string) string {
+	values := mc[strings.ToLower(key)]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores the key-value pair, replacing any existing values of key.
+func (mc MetadataCarrier) Set(key string, value string) {
+	mc[strings.ToLower(key)] = []string{value}
+}
+
+// Keys lists the keys stored in this carrier.
+func (mc MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // TextMapPropagator propagates cross-cutting concerns as key-value text
 // pairs within a carrier that travels in-band across process boundaries.
 type TextMapPropagator interface {
@@ -19,7 +19,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ctxKeyType uint
@@ -102,3 +105,26 @@ func TestCompositeTextMapPropagatorExtract(t *testing.T) {
 		t.Errorf("invalid extract order: %s", got)
 	}
 }
+
+func TestMetadataCarrier(t *testing.T) {
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	md := map[string][]string{}
+	prop := propagation.TraceContext{}
+	prop.Inject(ctx, propagation.MetadataCarrier(md))
+	assert.Equal(t, []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, md["traceparent"])
+
+	mc := propagation.MetadataCarrier(md)
+	assert.Equal(t, md["traceparent"][0], mc.Get("Traceparent"))
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate"}, mc.Keys())
+	mc.Set("Key", "value")
+	assert.Equal(t, []string{"value"}, md["key"])
+	assert.Equal(t, "", mc.Get("missing"))
+
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), mc))
+	assert.Equal(t, trace.SpanContextFromContext(ctx).WithRemote(true), got)
+}
@@ -17,8 +17,8 @@ package propagation // import "go.opentelemetry.io/otel/propagation"
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -43,7 +43,6 @@ const (
 type TraceContext struct{}
 
 var _ TextMapPropagator = TraceContext{}
-var traceCtxRegExp = regexp.MustCompile("^(?P<version>[0-9a-f]{2})-(?P<traceID>[a-f0-9]{32})-(?P<spanID>[a-f0-9]{16})-(?P<traceFlags>[a-f0-9]{2})(?:-.*)?$")
 
 // Inject set tracecontext from the Context into the carrier.
 func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
@@ -84,70 +83,107 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 
-	matches := traceCtxRegExp.FindStringSubmatch(h)
-
-	if len(matches) == 0 {
+	scc, err := parseTraceParent(h)
+	if err != nil {
 		return trace.SpanContext{}
 	}
+	scc.TraceState = parseTraceState(carrier.Get(tracestateHeader))
+	scc.Remote = true
 
-	if len(matches) < 5 { // four subgroups plus the overall match
+	sc := trace.NewSpanContext(scc)
+	if !sc.IsValid() {
 		return trace.SpanContext{}
 	}
 
-	if len(matches[1]) != 2 {
-		return trace.SpanContext{}
+	return sc
+}
+
+// ErrInvalidTraceParent is returned by ValidateTraceParent for a traceparent
+// header that is not valid. It is wrapped by an error describing the reason
+// the header is not valid.
+var ErrInvalidTraceParent = errors.New("invalid traceparent")
+
+// ValidateTraceParent returns an error describing why the W3C Trace Context
+// traceparent header value h is not valid, or nil if it is valid. The
+// TraceContext propagator extracts a remote SpanContext from h if, and only
+// if, no error is returned. This is intended to help debugging why no
+// SpanContext is extracted.
+//
+// The returned error wraps ErrInvalidTraceParent.
+func ValidateTraceParent(h string) error {
+	_, err := parseTraceParent(h)
+	return err
+}
+
+// parseTraceParent parses the traceparent header value h into the
+// SpanContextConfig it encodes.
+func parseTraceParent(h string) (trace.SpanContextConfig, error) {
+	var scc trace.SpanContextConfig
+	if h == "" {
+		return scc, fmt.Errorf("%w: empty header", ErrInvalidTraceParent)
+	}
+
+	// Future versions may append additional fields after the flags.
+	fields := strings.SplitN(h, "-", 5)
+	if len(fields) < 4 {
+		return scc, fmt.Errorf("%w: want 4 fields separated by \"-\", got %d", ErrInvalidTraceParent, len(fields))
 	}
-	ver, err := hex.DecodeString(matches[1])
+
+	ver, err := decodeTraceParentField("version", fields[0], 2)
 	if err != nil {
-		return trace.SpanContext{}
+		return scc, err
 	}
 	version := int(ver[0])
 	if version > maxVersion {
-		return trace.SpanContext{}
+		return scc, fmt.Errorf("%w: version %q is not allowed", ErrInvalidTraceParent, fields[0])
 	}
-
-	if version == 0 && len(matches) != 5 { // four subgroups plus the overall match
-		return trace.SpanContext{}
+	if version == supportedVersion && len(fields) != 4 {
+		return scc, fmt.Errorf("%w: version %.2x has no fields after the trace flags", ErrInvalidTraceParent, supportedVersion)
 	}
 
-	if len(matches[2]) != 32 {
-		return trace.SpanContext{}
+	tid, err := decodeTraceParentField("trace ID", fields[1], 32)
+	if err != nil {
+		return scc, err
+	}
+	copy(scc.TraceID[:], tid)
+	if !scc.TraceID.IsValid() {
+		return scc, fmt.Errorf("%w: trace ID is all zeros", ErrInvalidTraceParent)
 	}
 
-	var scc trace.SpanContextConfig
-
-	scc.TraceID, err = trace.TraceIDFromHex(matches[2][:32])
+	sid, err := decodeTraceParentField("span ID", fields[2], 16)
 	if err != nil {
-		return trace.SpanContext{}
+		return scc, err
 	}
-
-	if len(matches[3]) != 16 {
-		return trace.SpanContext{}
+	copy(scc.SpanID[:], sid)
+	if !scc.SpanID.IsValid() {
+		return scc, fmt.Errorf("%w: span ID is all zeros", ErrInvalidTraceParent)
 	}
-	scc.SpanID, err = trace.SpanIDFromHex(matches[3])
+
+	opts, err := decodeTraceParentField("trace flags", fields[3], 2)
 	if err != nil {
-		return trace.SpanContext{}
+		return scc, err
 	}
-
-	if len(matches[4]) != 2 {
-		return trace.SpanContext{}
-	}
-	opts, err := hex.DecodeString(matches[4])
-	if err != nil || len(opts) < 1 || (version == 0 && opts[0] > 2) {
-		return trace.SpanContext{}
+	if version == supportedVersion && opts[0] > 2 {
+		return scc, fmt.Errorf("%w: trace flags %q set bits unused by version %.2x", ErrInvalidTraceParent, fields[3], supportedVersion)
 	}
 	// Clear all flags other than the trace-context supported sampling bit.
 	scc.TraceFlags = trace.TraceFlags(opts[0]) & trace.FlagsSampled
 
-	scc.TraceState = parseTraceState(carrier.Get(tracestateHeader))
-	scc.Remote = true
+	return scc, nil
+}
 
-	sc := trace.NewSpanContext(scc)
-	if !sc.IsValid() {
-		return trace.SpanContext{}
+// decodeTraceParentField decodes the traceparent field value s of the
+// named field. An error is returned if s is not n lowercase hex characters.
+func decodeTraceParentField(name, s string, n int) ([]byte, error) {
+	if len(s) != n {
+		return nil, fmt.Errorf("%w: %s must be %d hex characters, got %d", ErrInvalidTraceParent, name, n, len(s))
 	}
-
-	return sc
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return nil, fmt.Errorf("%w: %s %q is not lowercase hex", ErrInvalidTraceParent, name, s)
+		}
+	}
+	return hex.DecodeString(s)
 }
 
 // Fields returns the keys who's values are set with Inject.
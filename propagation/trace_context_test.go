@@ -16,6 +16,7 @@ package propagation_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -92,16 +93,6 @@ func TestExtractValidTraceContextFromHTTPReq(t *testing.T) {
 				Remote:     true,
 			}),
 		},
-		{
-			name:   "valid b3Header ending in dash",
-			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-",
-			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
-				TraceID:    traceID,
-				SpanID:     spanID,
-				TraceFlags: trace.FlagsSampled,
-				Remote:     true,
-			}),
-		},
 		{
 			name:   "future valid b3Header ending in dash",
 			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09-",
@@ -140,6 +131,10 @@ func TestExtractInvalidTraceContextFromHTTPReq(t *testing.T) {
 			name:   "wrong version length",
 			header: "0000-00000000000000000000000000000000-0000000000000000-01",
 		},
+		{
+			name:   "version 00 ending in dash",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-",
+		},
 		{
 			name:   "wrong trace ID length",
 			header: "00-ab00000000000000000000000000000000-cd00000000000000-01",
@@ -371,3 +366,88 @@ func TestTraceStatePropagation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTraceParent(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{header: "02-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09-XYZxsf09"},
+		{
+			header: "",
+			want:   "invalid traceparent: empty header",
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			want:   `invalid traceparent: want 4 fields separated by "-", got 3`,
+		},
+		{
+			header: "0000-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:   "invalid traceparent: version must be 2 hex characters, got 4",
+		},
+		{
+			header: "A0-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:   `invalid traceparent: version "A0" is not lowercase hex`,
+		},
+		{
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want:   `invalid traceparent: version "ff" is not allowed`,
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+			want:   "invalid traceparent: trace ID must be 32 hex characters, got 30",
+		},
+		{
+			header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			want:   "invalid traceparent: trace ID is all zeros",
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-qw00000000000000-01",
+			want:   `invalid traceparent: span ID "qw00000000000000" is not lowercase hex`,
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			want:   "invalid traceparent: span ID is all zeros",
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0100",
+			want:   "invalid traceparent: trace flags must be 2 hex characters, got 4",
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09",
+			want:   `invalid traceparent: trace flags "09" set bits unused by version 00`,
+		},
+		{
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			want:   "invalid traceparent: version 00 has no fields after the trace flags",
+		},
+	}
+
+	prop := propagation.TraceContext{}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			err := propagation.ValidateTraceParent(tt.header)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else {
+				if err == nil || err.Error() != tt.want {
+					t.Errorf("got error %v, want %q", err, tt.want)
+				}
+				if !errors.Is(err, propagation.ErrInvalidTraceParent) {
+					t.Errorf("error %v does not wrap ErrInvalidTraceParent", err)
+				}
+			}
+
+			// The validation must match the extraction.
+			carrier := propagation.HeaderCarrier(http.Header{})
+			carrier.Set("traceparent", tt.header)
+			sc := trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+			if sc.IsValid() != (err == nil) {
+				t.Errorf("extracted SpanContext valid: %t, validation error: %v", sc.IsValid(), err)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ require (
 	github.com/google/go-cmp v0.5.5
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/otel v0.20.0
+	go.opentelemetry.io/otel/metric v0.20.0
 	go.opentelemetry.io/otel/oteltest v0.20.0
 	go.opentelemetry.io/otel/trace v0.20.0
 )
@@ -30,6 +31,8 @@ replace go.opentelemetry.io/otel/example/prometheus => ../example/prometheus
 
 replace go.opentelemetry.io/otel/example/zipkin => ../example/zipkin
 
+replace go.opentelemetry.io/otel/exporters/autoexport => ../exporters/autoexport
+
 replace go.opentelemetry.io/otel/exporters/metric/prometheus => ../exporters/metric/prometheus
 
 replace go.opentelemetry.io/otel/exporters/otlp => ../exporters/otlp
@@ -42,6 +45,8 @@ replace go.opentelemetry.io/otel/exporters/trace/zipkin => ../exporters/trace/zi
 
 replace go.opentelemetry.io/otel/internal/tools => ../internal/tools
 
+replace go.opentelemetry.io/otel/instrumentation/otelgrpc => ../instrumentation/otelgrpc
+
 replace go.opentelemetry.io/otel/sdk => ./
 
 replace go.opentelemetry.io/otel/metric => ../metric
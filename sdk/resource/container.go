@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+const (
+	// podNameEnvVar is the environment variable the pod name can be
+	// assigned to using the Kubernetes downward API.
+	podNameEnvVar = "K8S_POD_NAME"
+	// namespaceEnvVar is the environment variable the namespace name can
+	// be assigned to using the Kubernetes downward API.
+	namespaceEnvVar = "K8S_NAMESPACE_NAME"
+	// kubernetesEnvVar is set by Kubernetes in all containers.
+	kubernetesEnvVar = "KUBERNETES_SERVICE_HOST"
+)
+
+var (
+	defaultCgroupPath    = "/proc/self/cgroup"
+	defaultNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+var (
+	cgroupPath    = defaultCgroupPath
+	namespacePath = defaultNamespacePath
+)
+
+func setDefaultContainerProviders() {
+	setContainerProviders(defaultCgroupPath, defaultNamespacePath)
+}
+
+func setContainerProviders(cgroup, namespace string) {
+	cgroupPath = cgroup
+	namespacePath = namespace
+}
+
+// containerIDRegexp matches the container ID in a cgroup path, e.g.
+// "/docker/<id>" or "/kubepods/.../cri-containerd-<id>.scope".
+var containerIDRegexp = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+type containerDetector struct{}
+
+var _ Detector = containerDetector{}
+
+// Container returns a Detector that provides information about the
+// container and the Kubernetes pod the process is running in, as described
+// by WithContainer. It can be combined with other Detectors using Detect.
+func Container() Detector {
+	return containerDetector{}
+}
+
+// Detect returns a *Resource that describes the container and the Kubernetes
+// pod the process is running in. Information that is not available is
+// omitted. If a source of information exists but cannot be read, the
+// Resource is returned along with a wrapped ErrPartialResource error.
+func (containerDetector) Detect(ctx context.Context) (*Resource, error) {
+	var (
+		attrs  []attribute.KeyValue
+		errMsg []string
+	)
+
+	id, err := containerID()
+	if err != nil {
+		errMsg = append(errMsg, err.Error())
+	}
+	if id != "" {
+		attrs = append(attrs, semconv.ContainerIDKey.String(id))
+	}
+
+	pod, err := podName()
+	if err != nil {
+		errMsg = append(errMsg, err.Error())
+	}
+	if pod != "" {
+		attrs = append(attrs, semconv.K8SPodNameKey.String(pod))
+	}
+
+	ns, err := namespaceName()
+	if err != nil {
+		errMsg = append(errMsg, err.Error())
+	}
+	if ns != "" {
+		attrs = append(attrs, semconv.K8SNamespaceNameKey.String(ns))
+	}
+
+	res := NewWithAttributes(attrs...)
+	if len(errMsg) > 0 {
+		return res, fmt.Errorf("%w: %s", ErrPartialResource, strings.Join(errMsg, "; "))
+	}
+	return res, nil
+}
+
+// containerID returns the ID of the container found in the cgroup file, or
+// an empty string if the process is not running in a container.
+func containerID() (string, error) {
+	f, err := os.Open(cgroupPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// Each line has the "hierarchy-ID:controller-list:cgroup-path" format.
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if m := containerIDRegexp.FindStringSubmatch(fields[2]); m != nil {
+			return m[1], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// inKubernetes returns if the process is running in a Kubernetes pod.
+func inKubernetes() bool {
+	return os.Getenv(kubernetesEnvVar) != ""
+}
+
+// podName returns the name of the Kubernetes pod from the environment. If
+// it is not set, the host name, which Kubernetes sets to the pod name, is
+// used if the process runs in Kubernetes.
+func podName() (string, error) {
+	if name := strings.TrimSpace(os.Getenv(podNameEnvVar)); name != "" {
+		return name, nil
+	}
+	if !inKubernetes() {
+		return "", nil
+	}
+	return os.Hostname()
+}
+
+// namespaceName returns the name of the Kubernetes namespace from the
+// environment or, if it is not set, the service account of the pod.
+func namespaceName() (string, error) {
+	if name := strings.TrimSpace(os.Getenv(namespaceEnvVar)); name != "" {
+		return name, nil
+	}
+	if !inKubernetes() {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(namespacePath)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// WithContainer adds attributes describing the container, and the Kubernetes
+// pod and namespace, the process is running in to the configured Resource.
+//
+// The container ID is read from the cgroup of the process. The pod and
+// namespace names are read from the K8S_POD_NAME and K8S_NAMESPACE_NAME
+// environment variables, which can be set using the Kubernetes downward
+// API. If these are not set and the process is running in Kubernetes, the
+// host name and the namespace of the service account of the pod are used.
+func WithContainer() Option {
+	return WithDetectors(Container())
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+const fakeContainerID = "ac679f8a8319c8cf7d38e1adf263bc08d231f2ff81abda3915f6e8ba4d64156a"
+
+func TestWithContainer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "container")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cgroup := filepath.Join(dir, "cgroup")
+	require.NoError(t, ioutil.WriteFile(cgroup, []byte(
+		"12:pids:/kubepods/besteffort/pod0b4f/cri-containerd-"+fakeContainerID+".scope\n"+
+			"0::/\n",
+	), 0600))
+	namespace := filepath.Join(dir, "namespace")
+	require.NoError(t, ioutil.WriteFile(namespace, []byte("prod\n"), 0600))
+	missing := filepath.Join(dir, "missing")
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		envars    map[string]string
+		cgroup    string
+		namespace string
+		want      map[string]string
+	}{
+		{
+			name:      "not in a container",
+			cgroup:    missing,
+			namespace: missing,
+			want:      map[string]string{},
+		},
+		{
+			name:      "container",
+			cgroup:    cgroup,
+			namespace: missing,
+			want:      map[string]string{"container.id": fakeContainerID},
+		},
+		{
+			name:      "kubernetes",
+			envars:    map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"},
+			cgroup:    cgroup,
+			namespace: namespace,
+			want: map[string]string{
+				"container.id":       fakeContainerID,
+				"k8s.pod.name":       hostname,
+				"k8s.namespace.name": "prod",
+			},
+		},
+		{
+			name: "downward API",
+			envars: map[string]string{
+				"KUBERNETES_SERVICE_HOST": "10.0.0.1",
+				"K8S_POD_NAME":            "pod-1",
+				"K8S_NAMESPACE_NAME":      "staging",
+			},
+			cgroup:    missing,
+			namespace: namespace,
+			want: map[string]string{
+				"k8s.pod.name":       "pod-1",
+				"k8s.namespace.name": "staging",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envars := map[string]string{
+				"KUBERNETES_SERVICE_HOST": "",
+				"K8S_POD_NAME":            "",
+				"K8S_NAMESPACE_NAME":      "",
+			}
+			for k, v := range tt.envars {
+				envars[k] = v
+			}
+			store, err := ottest.SetEnvVariables(envars)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, store.Restore()) }()
+			resource.SetContainerProviders(tt.cgroup, tt.namespace)
+			defer resource.SetDefaultContainerProviders()
+
+			res, err := resource.New(context.Background(), resource.WithContainer())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toMap(res))
+		})
+	}
+}
+
+func TestWithContainerPartial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "container")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"KUBERNETES_SERVICE_HOST": "10.0.0.1",
+		"K8S_POD_NAME":            "pod-1",
+		"K8S_NAMESPACE_NAME":      "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+	// A directory cannot be read as the namespace file.
+	resource.SetContainerProviders(filepath.Join(dir, "missing"), dir)
+	defer resource.SetDefaultContainerProviders()
+
+	res, err := resource.New(context.Background(), resource.WithContainer())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), resource.ErrPartialResource.Error())
+	}
+	assert.Equal(t, map[string]string{"k8s.pod.name": "pod-1"}, toMap(res))
+}
+
+func TestContainerDetector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "container")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cgroup := filepath.Join(dir, "cgroup")
+	require.NoError(t, ioutil.WriteFile(cgroup, []byte("1:cpu:/docker/"+fakeContainerID+"\n"), 0600))
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"KUBERNETES_SERVICE_HOST": "",
+		"K8S_POD_NAME":            "pod-1",
+		"K8S_NAMESPACE_NAME":      "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+	resource.SetContainerProviders(cgroup, filepath.Join(dir, "missing"))
+	defer resource.SetDefaultContainerProviders()
+
+	res, err := resource.Detect(
+		context.Background(),
+		resource.Container(),
+		resource.StringDetector(semconv.ServiceNameKey, func() (string, error) { return "svc", nil }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"container.id": fakeContainerID,
+		"k8s.pod.name": "pod-1",
+		"service.name": "svc",
+	}, toMap(res))
+}
@@ -21,6 +21,9 @@ var (
 	SetRuntimeProviders        = setRuntimeProviders
 	SetDefaultUserProviders    = setDefaultUserProviders
 	SetUserProviders           = setUserProviders
+
+	SetDefaultContainerProviders = setDefaultContainerProviders
+	SetContainerProviders        = setContainerProviders
 )
 
 var (
@@ -21,6 +21,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"go.opentelemetry.io/otel/semconv"
 )
@@ -221,17 +222,52 @@ func WithProcessRuntimeDescription() Option {
 	return WithDetectors(processRuntimeDescriptionDetector{})
 }
 
+// processDetectors are the detectors of all the Process attributes.
+var processDetectors = []Detector{
+	processPIDDetector{},
+	processExecutableNameDetector{},
+	processExecutablePathDetector{},
+	processCommandArgsDetector{},
+	processOwnerDetector{},
+	processRuntimeNameDetector{},
+	processRuntimeVersionDetector{},
+	processRuntimeDescriptionDetector{},
+}
+
 // WithProcess adds all the Process attributes to the configured Resource.
 // See individual WithProcess* functions to configure specific attributes.
 func WithProcess() Option {
-	return WithDetectors(
-		processPIDDetector{},
-		processExecutableNameDetector{},
-		processExecutablePathDetector{},
-		processCommandArgsDetector{},
-		processOwnerDetector{},
-		processRuntimeNameDetector{},
-		processRuntimeVersionDetector{},
-		processRuntimeDescriptionDetector{},
+	return WithDetectors(processDetectors...)
+}
+
+type processDetector struct{}
+
+// Process returns a Detector that provides all the Process attributes. It
+// can be combined with other Detectors using Detect.
+//
+// If an attribute cannot be detected, e.g. the path of the executable
+// cannot be read due to missing permissions, the Resource is returned
+// without it and along with a wrapped ErrPartialResource error.
+func Process() Detector {
+	return processDetector{}
+}
+
+// Detect returns a *Resource that describes the process.
+func (processDetector) Detect(ctx context.Context) (*Resource, error) {
+	var (
+		res    *Resource
+		errMsg []string
 	)
+	for _, d := range processDetectors {
+		r, err := d.Detect(ctx)
+		if err != nil {
+			errMsg = append(errMsg, err.Error())
+			continue
+		}
+		res = Merge(res, r)
+	}
+	if len(errMsg) > 0 {
+		return res, fmt.Errorf("%w: %s", ErrPartialResource, strings.Join(errMsg, "; "))
+	}
+	return res, nil
 }
@@ -16,6 +16,7 @@ package resource_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/user"
@@ -115,6 +116,7 @@ func TestWithProcessFuncs(t *testing.T) {
 	t.Run("WithRuntimeVersion", testWithProcessRuntimeVersion)
 	t.Run("WithRuntimeDescription", testWithProcessRuntimeDescription)
 	t.Run("WithProcess", testWithProcess)
+	t.Run("Process", testProcessDetector)
 
 	restoreProcessAttributesProviders()
 }
@@ -124,6 +126,7 @@ func TestWithProcessFuncsErrors(t *testing.T) {
 
 	t.Run("WithPID", testWithProcessExecutablePathError)
 	t.Run("WithExecutableName", testWithProcessOwnerError)
+	t.Run("Process", testProcessDetectorError)
 
 	restoreProcessAttributesProviders()
 }
@@ -268,6 +271,22 @@ func testWithProcess(t *testing.T) {
 	}, toMap(res))
 }
 
+func testProcessDetector(t *testing.T) {
+	res, err := resource.Detect(context.Background(), resource.Process())
+
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{
+		"process.pid":                 fmt.Sprint(fakePID),
+		"process.executable.name":     fakeExecutableName,
+		"process.executable.path":     fakeExecutablePath,
+		"process.command_args":        fmt.Sprint(fakeCommandArgs),
+		"process.owner":               fakeOwner,
+		"process.runtime.name":        fakeRuntimeName,
+		"process.runtime.version":     fakeRuntimeVersion,
+		"process.runtime.description": fakeRuntimeDescription,
+	}, toMap(res))
+}
+
 func testWithProcessExecutablePathError(t *testing.T) {
 	ctx := context.Background()
 
@@ -289,3 +308,17 @@ func testWithProcessOwnerError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualValues(t, map[string]string{}, toMap(res))
 }
+
+func testProcessDetectorError(t *testing.T) {
+	res, err := resource.Process().Detect(context.Background())
+
+	require.True(t, errors.Is(err, resource.ErrPartialResource))
+	require.EqualValues(t, map[string]string{
+		"process.pid":                 fmt.Sprint(fakePID),
+		"process.executable.name":     fakeExecutableName,
+		"process.command_args":        fmt.Sprint(fakeCommandArgs),
+		"process.runtime.name":        fakeRuntimeName,
+		"process.runtime.version":     fakeRuntimeVersion,
+		"process.runtime.description": fakeRuntimeDescription,
+	}, toMap(res))
+}
@@ -16,6 +16,7 @@ package resource // import "go.opentelemetry.io/otel/sdk/resource"
 
 import (
 	"context"
+	"hash/fnv"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -76,7 +77,8 @@ func NewWithAttributes(attrs ...attribute.KeyValue) *Resource {
 }
 
 // String implements the Stringer interface and provides a
-// human-readable form of the resource.
+// human-readable form of the resource. It is canonical: the attributes are
+// sorted by key, equal resources have the same representation.
 //
 // Avoid using this representation as the key in a map of resources,
 // use Equivalent() as the key instead.
@@ -116,6 +118,26 @@ func (r *Resource) Equal(eq *Resource) bool {
 	return r.Equivalent() == eq.Equivalent()
 }
 
+// Hash returns a hash of the attributes of r. Equal resources, whatever the
+// order their attributes were passed in, have the same hash, which is
+// stable across processes and releases. Different resources can have the
+// same hash: use Equal to tell them apart, or Equivalent() as the key of a
+// map of resources within a process.
+//
+// The hash is computed at every call, it should be kept when it is used
+// repeatedly.
+func (r *Resource) Hash() uint64 {
+	h := fnv.New64a()
+	for iter := r.Iter(); iter.Next(); {
+		kv := iter.Label()
+		_, _ = h.Write([]byte(kv.Key))
+		_, _ = h.Write([]byte{0, byte(kv.Value.Type())})
+		_, _ = h.Write([]byte(kv.Value.Emit()))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
 // Merge creates a new resource by combining resource a and b.
 //
 // If there are common keys between resource a and b, then the value
@@ -149,7 +171,9 @@ func Empty() *Resource {
 }
 
 // Default returns an instance of Resource with a default
-// "service.name" and OpenTelemetrySDK attributes
+// "service.name" and OpenTelemetrySDK attributes, and the attributes
+// extracted from the OTEL_RESOURCE_ATTRIBUTES environment variable. The
+// OpenTelemetrySDK attributes take precedence over the environment.
 func Default() *Resource {
 	return defaultResource
 }
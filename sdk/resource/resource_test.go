@@ -257,6 +257,40 @@ func TestString(t *testing.T) {
 
 const envVar = "OTEL_RESOURCE_ATTRIBUTES"
 
+func TestEqualIgnoresOrder(t *testing.T) {
+	a := resource.NewWithAttributes(kv11, kv21, kv31)
+	b := resource.NewWithAttributes(kv31, kv11, kv21)
+	require.True(t, a.Equal(b))
+	require.Equal(t, a.String(), b.String())
+	require.Equal(t, "k1=v11,k2=v21,k3=v31", b.String())
+	require.False(t, a.Equal(resource.NewWithAttributes(kv11, kv21)))
+}
+
+func TestHash(t *testing.T) {
+	a := resource.NewWithAttributes(kv11, kv21, kv31)
+	b := resource.NewWithAttributes(kv31, kv11, kv21)
+	require.Equal(t, a.Hash(), b.Hash())
+	// The hash is stable, it can be persisted.
+	require.Equal(t, uint64(0x1043c50bf9abbc5d), a.Hash())
+
+	differ := []*resource.Resource{
+		resource.NewWithAttributes(kv12, kv21, kv31),
+		resource.NewWithAttributes(kv11, kv21),
+		resource.NewWithAttributes(attribute.String("k1", "v11,k2=v21"), kv31),
+		resource.NewWithAttributes(attribute.Int64("k1", 1)),
+		resource.NewWithAttributes(attribute.String("k1", "1")),
+		resource.Empty(),
+	}
+	seen := map[uint64]bool{a.Hash(): true}
+	for _, r := range differ {
+		require.False(t, seen[r.Hash()], "hash of %s collides", r)
+		seen[r.Hash()] = true
+	}
+
+	var nilResource *resource.Resource
+	require.Equal(t, resource.Empty().Hash(), nilResource.Hash())
+}
+
 func TestMarshalJSON(t *testing.T) {
 	r := resource.NewWithAttributes(attribute.Int64("A", 1), attribute.String("C", "D"))
 	data, err := json.Marshal(r)
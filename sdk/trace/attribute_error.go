@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// InvalidAttributeError is reported to the global error handler for every
+// invalid attribute dropped by a span. Attributes are invalid if their key
+// is empty, their value is not set, or their value is, or contains, a NaN or
+// infinite float which cannot be encoded in JSON.
+type InvalidAttributeError struct {
+	// Attribute is the dropped attribute.
+	Attribute attribute.KeyValue
+	// Reason describes why the attribute is invalid.
+	Reason string
+}
+
+func (e *InvalidAttributeError) Error() string {
+	return fmt.Sprintf("invalid span attribute %q dropped: %s", e.Attribute.Key, e.Reason)
+}
+
+// validateAttribute returns an *InvalidAttributeError if kv is invalid.
+func validateAttribute(kv attribute.KeyValue) error {
+	var reason string
+	switch {
+	case kv.Key == "":
+		reason = "empty key"
+	case kv.Value.Type() == attribute.INVALID:
+		reason = "invalid value"
+	case kv.Value.Type() == attribute.FLOAT64 && !isFinite(kv.Value.AsFloat64()):
+		reason = "non-finite float value"
+	case kv.Value.Type() == attribute.ARRAY && !finiteArray(kv.Value.AsArray()):
+		reason = "non-finite float in array value"
+	default:
+		return nil
+	}
+	return &InvalidAttributeError{Attribute: kv, Reason: reason}
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// finiteArray returns if the array arr contains no NaN or infinite float.
+func finiteArray(arr interface{}) bool {
+	v := reflect.ValueOf(arr)
+	if k := v.Type().Elem().Kind(); k != reflect.Float64 && k != reflect.Float32 {
+		return true
+	}
+	for i := 0; i < v.Len(); i++ {
+		if !isFinite(v.Index(i).Float()) {
+			return false
+		}
+	}
+	return true
+}
@@ -16,6 +16,7 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
 	"container/list"
+	"reflect"
 
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -31,28 +32,68 @@ type attributesMap struct {
 	evictList    *list.List
 	droppedCount int
 	capacity     int
+
+	// sizeHint is the number of attributes the map is expected to hold.
+	sizeHint int
+
+	// bytesLimit is the maximum sum of the sizes of the attribute values,
+	// unlimited if not positive. bytes is the current sum.
+	bytesLimit int
+	bytes      int
 }
 
+// newAttributesMap returns an attributesMap holding up to capacity
+// attributes. Its storage is only allocated once an attribute is added.
 func newAttributesMap(capacity int) *attributesMap {
 	lm := &attributesMap{
-		attributes: make(map[attribute.Key]*list.Element),
-		evictList:  list.New(),
-		capacity:   capacity,
+		capacity: capacity,
 	}
 	return lm
 }
 
+// len returns the number of attributes in am. It is safe to call on a nil am.
+func (am *attributesMap) len() int {
+	if am == nil || am.evictList == nil {
+		return 0
+	}
+	return am.evictList.Len()
+}
+
 func (am *attributesMap) add(kv attribute.KeyValue) {
+	if am.evictList == nil {
+		am.attributes = make(map[attribute.Key]*list.Element, am.sizeHint)
+		am.evictList = list.New()
+	}
+
+	size := valueSize(kv.Value)
+
 	// Check for existing item
 	if ent, ok := am.attributes[kv.Key]; ok {
+		old := valueSize(ent.Value.(*attribute.KeyValue).Value)
+		if am.exceedsBytes(size - old) {
+			am.droppedCount++
+			return
+		}
+		am.bytes += size - old
 		am.evictList.MoveToFront(ent)
 		ent.Value = &kv
 		return
 	}
 
+	// The oldest attribute is evicted if the map is full, freeing its bytes.
+	freed := 0
+	if am.evictList.Len() >= am.capacity && am.evictList.Len() > 0 {
+		freed = valueSize(am.evictList.Back().Value.(*attribute.KeyValue).Value)
+	}
+	if am.exceedsBytes(size - freed) {
+		am.droppedCount++
+		return
+	}
+
 	// Add new item
 	entry := am.evictList.PushFront(&kv)
 	am.attributes[kv.Key] = entry
+	am.bytes += size
 
 	// Verify size not exceeded
 	if am.evictList.Len() > am.capacity {
@@ -65,7 +106,7 @@ func (am *attributesMap) add(kv attribute.KeyValue) {
 // returns it. If the map is empty, a nil is returned.
 // TODO: Is it more efficient to return a pointer to the slice?
 func (am *attributesMap) toKeyValue() []attribute.KeyValue {
-	len := am.evictList.Len()
+	len := am.len()
 	if len == 0 {
 		return nil
 	}
@@ -82,10 +123,51 @@ func (am *attributesMap) toKeyValue() []attribute.KeyValue {
 
 // removeOldest removes the oldest item from the cache.
 func (am *attributesMap) removeOldest() {
+	if am.len() == 0 {
+		return
+	}
 	ent := am.evictList.Back()
 	if ent != nil {
 		am.evictList.Remove(ent)
 		kv := ent.Value.(*attribute.KeyValue)
 		delete(am.attributes, kv.Key)
+		am.bytes -= valueSize(kv.Value)
+	}
+}
+
+// exceedsBytes returns if adding delta bytes of attribute values to am
+// exceeds its bytes limit.
+func (am *attributesMap) exceedsBytes(delta int) bool {
+	return am.bytesLimit > 0 && am.bytes+delta > am.bytesLimit
+}
+
+// valueSize returns the size in bytes of v: the length of strings and byte
+// slices, and the size of the encoding of numbers and booleans. The size of
+// an array is the sum of the sizes of its elements.
+func valueSize(v attribute.Value) int {
+	switch v.Type() {
+	case attribute.BOOL:
+		return 1
+	case attribute.STRING:
+		return len(v.AsString())
+	case attribute.BYTES:
+		return len(v.AsBytes())
+	case attribute.ARRAY:
+		rv := reflect.ValueOf(v.AsArray())
+		if rv.Kind() != reflect.Array {
+			return 0
+		}
+		if rv.Type().Elem().Kind() != reflect.String {
+			return rv.Len() * int(rv.Type().Elem().Size())
+		}
+		n := 0
+		for i := 0; i < rv.Len(); i++ {
+			n += rv.Index(i).Len()
+		}
+		return n
+	case attribute.INVALID:
+		return 0
 	}
+	// 64-bit numbers.
+	return 8
 }
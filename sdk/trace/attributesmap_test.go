@@ -101,3 +101,57 @@ func BenchmarkAttributesMapToKeyValue(b *testing.B) {
 		attrMap.toKeyValue()
 	}
 }
+
+func TestAttributesMapBytesLimit(t *testing.T) {
+	attrMap := newAttributesMap(128)
+	attrMap.bytesLimit = 20
+
+	attrMap.add(attribute.String("a", "0123456789"))
+	attrMap.add(attribute.Int("b", 1))
+	// Exceeds the limit with 10+8+3 bytes.
+	attrMap.add(attribute.String("c", "abc"))
+	attrMap.add(attribute.Bool("d", true))
+	// Updating a value accounts for the size difference.
+	attrMap.add(attribute.String("a", "01234567890"))
+	attrMap.add(attribute.String("a", "012345678901"))
+
+	want := []attribute.KeyValue{
+		attribute.Int("b", 1),
+		attribute.Bool("d", true),
+		attribute.String("a", "01234567890"),
+	}
+	got := attrMap.toKeyValue()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("attrMap.toKeyValue(): got %v; want %v", got, want)
+	}
+	if attrMap.bytes != 20 {
+		t.Errorf("attrMap.bytes: got '%d'; want '%d'", attrMap.bytes, 20)
+	}
+	if attrMap.droppedCount != 2 {
+		t.Errorf("attrMap.droppedCount: got '%d'; want '%d'", attrMap.droppedCount, 2)
+	}
+}
+
+func TestAttributesMapBytesLimitEviction(t *testing.T) {
+	attrMap := newAttributesMap(2)
+	attrMap.bytesLimit = 10
+
+	attrMap.add(attribute.String("a", "01234"))
+	attrMap.add(attribute.String("b", "01234"))
+	// Evicting the oldest attribute frees its bytes.
+	attrMap.add(attribute.Array("c", []string{"ab", "c"}))
+	if attrMap.bytes != 8 {
+		t.Errorf("attrMap.bytes: got '%d'; want '%d'", attrMap.bytes, 8)
+	}
+	// Still exceeds the limit once the oldest attribute is evicted.
+	attrMap.add(attribute.Array("d", []int64{1}))
+	if attrMap.bytes != 8 {
+		t.Errorf("attrMap.bytes: got '%d'; want '%d'", attrMap.bytes, 8)
+	}
+	if _, ok := attrMap.attributes["b"]; !ok {
+		t.Error("key \"b\" should not be evicted")
+	}
+	if attrMap.droppedCount != 2 {
+		t.Errorf("attrMap.droppedCount: got '%d'; want '%d'", attrMap.droppedCount, 2)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// BaggageKeyPrefix is the prefix of the keys of the attributes recording
+// the baggage of a span with the WithRecordBaggage option.
+const BaggageKeyPrefix = "baggage."
+
+// baggageAttributes returns the baggage of ctx as attributes with their keys
+// prefixed by BaggageKeyPrefix.
+func baggageAttributes(ctx context.Context) []attribute.KeyValue {
+	set := baggage.Set(ctx)
+	if set.Len() == 0 {
+		return nil
+	}
+	attrs := make([]attribute.KeyValue, 0, set.Len())
+	for iter := set.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		attrs = append(attrs, attribute.KeyValue{
+			Key:   attribute.Key(BaggageKeyPrefix + string(kv.Key)),
+			Value: kv.Value,
+		})
+	}
+	return attrs
+}
@@ -65,6 +65,11 @@ type BatchSpanProcessorOptions struct {
 // batchSpanProcessor is a SpanProcessor that batches asynchronously-received
 // SpanSnapshots and sends them to a trace.Exporter when complete.
 type batchSpanProcessor struct {
+	// exported and exportErrors are accessed atomically and declared first
+	// to ensure their 64-bit alignment.
+	exported     uint64
+	exportErrors uint64
+
 	e SpanExporter
 	o BatchSpanProcessorOptions
 
@@ -77,6 +82,12 @@ type batchSpanProcessor struct {
 	stopWait   sync.WaitGroup
 	stopOnce   sync.Once
 	stopCh     chan struct{}
+
+	// exportCtx is the context of the exports made by the processing
+	// goroutine. It is cancelled when Shutdown returns so exports still in
+	// progress do not outlive its deadline.
+	exportCtx    context.Context
+	exportCancel context.CancelFunc
 }
 
 var _ SpanProcessor = (*batchSpanProcessor)(nil)
@@ -103,6 +114,7 @@ func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorO
 		queue:  make(chan *SpanSnapshot, o.MaxQueueSize),
 		stopCh: make(chan struct{}),
 	}
+	bsp.exportCtx, bsp.exportCancel = context.WithCancel(context.Background())
 
 	bsp.stopWait.Add(1)
 	go func() {
@@ -128,9 +140,14 @@ func (bsp *batchSpanProcessor) OnEnd(s ReadOnlySpan) {
 
 // Shutdown flushes the queue and waits until all spans are processed.
 // It only executes once. Subsequent call does nothing.
+//
+// Spans ended after Shutdown is called are dropped. If ctx is done before the
+// queue is flushed, the exports still in progress are cancelled and the
+// context error is returned, e.g. context.DeadlineExceeded.
 func (bsp *batchSpanProcessor) Shutdown(ctx context.Context) error {
 	var err error
 	bsp.stopOnce.Do(func() {
+		defer bsp.exportCancel()
 		wait := make(chan struct{})
 		go func() {
 			close(bsp.stopCh)
@@ -224,8 +241,10 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 		bsp.batch = bsp.batch[:0]
 
 		if err != nil {
+			atomic.AddUint64(&bsp.exportErrors, 1)
 			return err
 		}
+		atomic.AddUint64(&bsp.exported, uint64(l))
 	}
 	return nil
 }
@@ -236,8 +255,7 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 func (bsp *batchSpanProcessor) processQueue() {
 	defer bsp.timer.Stop()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := bsp.exportCtx
 	for {
 		select {
 		case <-bsp.stopCh:
@@ -266,8 +284,7 @@ func (bsp *batchSpanProcessor) processQueue() {
 // drainQueue awaits the any caller that had added to bsp.stopWait
 // to finish the enqueue, then exports the final batch.
 func (bsp *batchSpanProcessor) drainQueue() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := bsp.exportCtx
 	for {
 		select {
 		case sd := <-bsp.queue:
@@ -331,3 +348,11 @@ func (bsp *batchSpanProcessor) enqueue(sd *SpanSnapshot) {
 		atomic.AddUint32(&bsp.dropped, 1)
 	}
 }
+
+func (bsp *batchSpanProcessor) exportCounts() exportCounts {
+	return exportCounts{
+		dropped:  uint64(atomic.LoadUint32(&bsp.dropped)),
+		exported: atomic.LoadUint64(&bsp.exported),
+		errors:   atomic.LoadUint64(&bsp.exportErrors),
+	}
+}
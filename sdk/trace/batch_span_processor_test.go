@@ -448,3 +448,38 @@ func TestBatchSpanProcessorForceFlushCancellation(t *testing.T) {
 		t.Errorf("expected %q error, got %v", want, got)
 	}
 }
+
+// slowExporter blocks exports until their context is done.
+type slowExporter struct {
+	cancelled chan struct{}
+	once      sync.Once
+}
+
+func (*slowExporter) Shutdown(context.Context) error { return nil }
+func (e *slowExporter) ExportSpans(ctx context.Context, _ []*sdktrace.SpanSnapshot) error {
+	<-ctx.Done()
+	e.once.Do(func() { close(e.cancelled) })
+	return ctx.Err()
+}
+
+func TestBatchSpanProcessorShutdownTimeout(t *testing.T) {
+	exp := &slowExporter{cancelled: make(chan struct{})}
+	bsp := sdktrace.NewBatchSpanProcessor(exp)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+
+	_, span := tp.Tracer("ShutdownTimeout").Start(context.Background(), "span")
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if got, want := bsp.Shutdown(ctx), context.DeadlineExceeded; !errors.Is(got, want) {
+		t.Errorf("expected %q error, got %v", want, got)
+	}
+
+	select {
+	case <-exp.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("export in progress not cancelled after Shutdown timeout")
+	}
+}
@@ -16,6 +16,7 @@ package trace_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -115,6 +116,46 @@ func BenchmarkSpanWithAttributes_all_2x(b *testing.B) {
 	})
 }
 
+func BenchmarkSpanWithEventsAndLinks(b *testing.B) {
+	traceBenchmark(b, "Benchmark SpanWithEventsAndLinks", func(b *testing.B, t trace.Tracer) {
+		ctx := context.Background()
+		link := trace.Link{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x01},
+		})}
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_, span := t.Start(ctx, "/foo", trace.WithLinks(link))
+			span.AddEvent("event", trace.WithAttributes(attribute.Bool("key1", false)))
+			span.End()
+		}
+	})
+}
+
+func BenchmarkSpanWithAttributeCapacity(b *testing.B) {
+	attrs := make([]attribute.KeyValue, 10)
+	for i := range attrs {
+		attrs[i] = attribute.Int(fmt.Sprintf("key%d", i), i)
+	}
+	for _, n := range []int{0, len(attrs)} {
+		b.Run(fmt.Sprintf("Capacity%d", n), func(b *testing.B) {
+			t := tracer(b, "Benchmark SpanWithAttributeCapacity", sdktrace.AlwaysSample())
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, span := t.Start(ctx, "/foo", trace.WithAttributeCapacity(n))
+				for _, a := range attrs {
+					span.SetAttributes(a)
+				}
+				span.End()
+			}
+		})
+	}
+}
+
 func BenchmarkTraceID_DotString(b *testing.B) {
 	t, _ := trace.TraceIDFromHex("0000000000000001000000000000002a")
 	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: t})
@@ -152,3 +193,62 @@ func tracer(b *testing.B, name string, sampler sdktrace.Sampler) trace.Tracer {
 	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler))
 	return tp.Tracer(name)
 }
+
+func BenchmarkSpanSnapshotClone(b *testing.B) {
+	attrs := []attribute.KeyValue{
+		attribute.String("key1", "value1"),
+		attribute.Int64("key2", 2),
+		attribute.Bool("key3", true),
+		attribute.Float64("key4", 4.0),
+	}
+	ss := &sdktrace.SpanSnapshot{
+		Name:       "/foo",
+		Attributes: attrs,
+		MessageEvents: []sdktrace.Event{
+			{Name: "event1", Attributes: attrs},
+			{Name: "event2", Attributes: attrs},
+		},
+		Links: []trace.Link{{Attributes: attrs}},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ss.Clone()
+	}
+}
+
+// releasingExporter optionally releases the SpanSnapshots it exports.
+type releasingExporter struct {
+	release bool
+}
+
+func (e releasingExporter) ExportSpans(_ context.Context, ss []*sdktrace.SpanSnapshot) error {
+	if e.release {
+		sdktrace.ReleaseSpanSnapshots(ss)
+	}
+	return nil
+}
+
+func (releasingExporter) Shutdown(context.Context) error { return nil }
+
+func BenchmarkSpanSnapshotRelease(b *testing.B) {
+	for _, release := range []bool{false, true} {
+		name := "Retained"
+		if release {
+			name = "Released"
+		}
+		b.Run(name, func(b *testing.B) {
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(releasingExporter{release: release}))
+			t := tp.Tracer("Benchmark SpanSnapshotRelease")
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, span := t.Start(ctx, "/foo")
+				span.End()
+			}
+		})
+	}
+}
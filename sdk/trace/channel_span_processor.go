@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// channelSpanProcessor is a SpanProcessor that sends the SpanSnapshots of
+// the ended spans to a channel.
+type channelSpanProcessor struct {
+	// exported and dropped are accessed atomically and declared first to
+	// ensure their 64-bit alignment.
+	exported uint64
+	dropped  uint64
+
+	// stopped is set atomically by Shutdown so OnEnd returns without
+	// waiting for mu once the processor is shut down.
+	stopped int32
+
+	dropOnFull bool
+
+	// mu is held for reading while sending to ch, and for writing to close
+	// it, so it is not closed while a send is in progress.
+	mu     sync.RWMutex
+	ch     chan<- *SpanSnapshot
+	closed bool
+
+	// abort is closed to abandon the blocked sends when the context of
+	// Shutdown is done before they complete.
+	abort    chan struct{}
+	stopOnce sync.Once
+}
+
+var _ SpanProcessor = (*channelSpanProcessor)(nil)
+
+// NewChannelProcessor returns a SpanProcessor sending the SpanSnapshots of
+// the sampled spans to ch when they end. This lets custom consumers, e.g.
+// the event loop of an application or a test, process the spans without
+// implementing a SpanExporter.
+//
+// If dropOnFull is true, the spans that cannot be sent because ch is full
+// are dropped, and accounted for in the Stats of the TracerProvider.
+// Otherwise, OnEnd blocks until they are received. Blocking should be used
+// carefully as a slow consumer then slows down the application.
+//
+// Shutdown waits for the sends in progress to complete and closes ch. The
+// spans ended after are dropped. If ch is nil, all spans are dropped.
+func NewChannelProcessor(ch chan<- *SpanSnapshot, dropOnFull bool) SpanProcessor {
+	return &channelSpanProcessor{
+		ch:         ch,
+		dropOnFull: dropOnFull,
+		abort:      make(chan struct{}),
+	}
+}
+
+// OnStart does nothing.
+func (p *channelSpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnd sends the SpanSnapshot of s to the channel if s is sampled.
+func (p *channelSpanProcessor) OnEnd(s ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() || atomic.LoadInt32(&p.stopped) != 0 {
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.ch == nil || p.closed {
+		return
+	}
+	ss := s.Snapshot()
+	if !p.dropOnFull {
+		select {
+		case p.ch <- ss:
+			atomic.AddUint64(&p.exported, 1)
+		case <-p.abort:
+			atomic.AddUint64(&p.dropped, 1)
+		}
+		return
+	}
+	select {
+	case p.ch <- ss:
+		atomic.AddUint64(&p.exported, 1)
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+	}
+}
+
+// Shutdown closes the channel once the sends in progress complete. It only
+// executes once. Subsequent calls do nothing.
+//
+// If ctx is done before, the context error is returned, the sends in
+// progress are abandoned, dropping their spans, and the channel is closed
+// once they return.
+func (p *channelSpanProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	p.stopOnce.Do(func() {
+		atomic.StoreInt32(&p.stopped, 1)
+		done := make(chan struct{})
+		go func() {
+			p.mu.Lock()
+			p.closed = true
+			if p.ch != nil {
+				close(p.ch)
+			}
+			p.mu.Unlock()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			close(p.abort)
+			err = ctx.Err()
+		}
+	})
+	return err
+}
+
+// ForceFlush does nothing as there is no data to flush.
+func (p *channelSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (p *channelSpanProcessor) exportCounts() exportCounts {
+	return exportCounts{
+		dropped:  atomic.LoadUint64(&p.dropped),
+		exported: atomic.LoadUint64(&p.exported),
+	}
+}
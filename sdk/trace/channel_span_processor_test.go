@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestChannelProcessorDropOnFull(t *testing.T) {
+	ch := make(chan *sdktrace.SpanSnapshot, 1)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sdktrace.NewChannelProcessor(ch, true)))
+	tr := tp.Tracer("ChannelProcessor")
+	for _, name := range []string{"first", "second"} {
+		_, span := tr.Start(context.Background(), name)
+		span.End()
+	}
+
+	stats := tp.Stats()
+	assert.Equal(t, uint64(1), stats.SpansExported)
+	assert.Equal(t, uint64(1), stats.SpansDropped)
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	var names []string
+	for ss := range ch {
+		names = append(names, ss.Name)
+	}
+	assert.Equal(t, []string{"first"}, names)
+
+	// Spans ended after the shutdown are dropped without panicking.
+	_, span := tr.Start(context.Background(), "after")
+	span.End()
+}
+
+func TestChannelProcessorBlocking(t *testing.T) {
+	ch := make(chan *sdktrace.SpanSnapshot)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sdktrace.NewChannelProcessor(ch, false)))
+	tr := tp.Tracer("ChannelProcessor")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}()
+
+	select {
+	case ss := <-ch:
+		assert.Equal(t, "span", ss.Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("span not sent")
+	}
+	<-done
+	assert.Equal(t, uint64(1), tp.Stats().SpansExported)
+	require.NoError(t, tp.Shutdown(context.Background()))
+	_, ok := <-ch
+	assert.False(t, ok, "channel not closed")
+}
+
+func TestChannelProcessorShutdownUnreadChannel(t *testing.T) {
+	ch := make(chan *sdktrace.SpanSnapshot)
+	sp := sdktrace.NewChannelProcessor(ch, false)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp))
+	tr := tp.Tracer("ChannelProcessor")
+
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		_, span := tr.Start(context.Background(), "blocked")
+		span.End()
+	}()
+	// Let the send block as nothing reads ch.
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sp.Shutdown(ctx), context.DeadlineExceeded)
+
+	ended := make(chan struct{})
+	go func() {
+		defer close(ended)
+		_, span := tr.Start(context.Background(), "after")
+		span.End()
+	}()
+	for _, c := range []chan struct{}{blocked, ended} {
+		select {
+		case <-c:
+		case <-time.After(5 * time.Second):
+			t.Fatal("OnEnd blocked after Shutdown")
+		}
+	}
+
+	_, ok := <-ch
+	assert.False(t, ok, "channel not closed")
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults for CircuitBreakerOptions.
+const (
+	DefaultFailureThreshold = 5
+	DefaultResetTimeout     = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by the exporters returned by
+// NewCircuitBreakerExporter when spans are exported while their circuit is
+// open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitBreakerOption func(o *CircuitBreakerOptions)
+
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed exports opening
+	// the circuit. The default value of FailureThreshold is 5.
+	FailureThreshold int
+
+	// ResetTimeout is the duration the circuit stays open before an export
+	// is tried again. The default value of ResetTimeout is 30 seconds.
+	ResetTimeout time.Duration
+}
+
+// WithFailureThreshold sets the number of consecutive failed exports
+// opening the circuit.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.FailureThreshold = n
+	}
+}
+
+// WithResetTimeout sets the duration the circuit stays open before an export
+// is tried again.
+func WithResetTimeout(d time.Duration) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.ResetTimeout = d
+	}
+}
+
+// circuitState is the state of a circuit breaker.
+type circuitState int
+
+const (
+	// circuitClosed passes the exports to the next exporter.
+	circuitClosed circuitState = iota
+	// circuitOpen fails the exports without passing them.
+	circuitOpen
+	// circuitHalfOpen passes a single export to the next exporter to
+	// decide if the circuit is closed or opened again.
+	circuitHalfOpen
+)
+
+// circuitBreakerExporter is a SpanExporter that stops passing the exports to
+// the next SpanExporter while it keeps failing.
+type circuitBreakerExporter struct {
+	next SpanExporter
+	o    CircuitBreakerOptions
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+
+	mu    sync.Mutex
+	state circuitState
+	// failures is the number of consecutive failed exports.
+	failures int
+	// openedAt is the time the circuit last opened.
+	openedAt time.Time
+}
+
+var _ SpanExporter = (*circuitBreakerExporter)(nil)
+
+// NewCircuitBreakerExporter returns a SpanExporter passing the exports to
+// next until FailureThreshold consecutive exports fail. The circuit is then
+// open: the exports fail with ErrCircuitOpen without being passed to next,
+// and the spans are dropped. This protects the application from wasting
+// resources on a backend that is down. Once ResetTimeout has elapsed, the
+// circuit is half-open: the next export is passed to next, while the
+// concurrent ones still fail. The circuit is closed if it succeeds, or open
+// again for ResetTimeout otherwise.
+func NewCircuitBreakerExporter(next SpanExporter, options ...CircuitBreakerOption) SpanExporter {
+	o := CircuitBreakerOptions{
+		FailureThreshold: DefaultFailureThreshold,
+		ResetTimeout:     DefaultResetTimeout,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = DefaultFailureThreshold
+	}
+	if o.ResetTimeout <= 0 {
+		o.ResetTimeout = DefaultResetTimeout
+	}
+	return &circuitBreakerExporter{next: next, o: o, now: time.Now}
+}
+
+// ExportSpans passes ss to the next SpanExporter unless the circuit is open.
+func (e *circuitBreakerExporter) ExportSpans(ctx context.Context, ss []*SpanSnapshot) error {
+	if !e.allow() {
+		return ErrCircuitOpen
+	}
+	err := e.next.ExportSpans(ctx, ss)
+	e.record(err)
+	return err
+}
+
+// allow returns if an export is passed to the next SpanExporter, half-opening
+// the circuit if it has been open for ResetTimeout.
+func (e *circuitBreakerExporter) allow() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch e.state {
+	case circuitOpen:
+		if e.now().Sub(e.openedAt) < e.o.ResetTimeout {
+			return false
+		}
+		e.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// An export is already being tried.
+		return false
+	}
+	return true
+}
+
+// record updates the state of the circuit with the result err of an export
+// passed to the next SpanExporter.
+func (e *circuitBreakerExporter) record(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == nil {
+		e.state = circuitClosed
+		e.failures = 0
+		return
+	}
+	e.failures++
+	if e.state == circuitHalfOpen || e.failures >= e.o.FailureThreshold {
+		e.state = circuitOpen
+		e.openedAt = e.now()
+	}
+}
+
+// Shutdown shuts down the next SpanExporter.
+func (e *circuitBreakerExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
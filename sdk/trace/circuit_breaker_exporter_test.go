@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingExporter fails its exports with err and counts them.
+type failingExporter struct {
+	err     error
+	exports int
+}
+
+func (e *failingExporter) ExportSpans(context.Context, []*SpanSnapshot) error {
+	e.exports++
+	return e.err
+}
+
+func (e *failingExporter) Shutdown(context.Context) error { return nil }
+
+func newTestCircuitBreaker(next SpanExporter, now *time.Time) *circuitBreakerExporter {
+	e := NewCircuitBreakerExporter(next, WithFailureThreshold(3), WithResetTimeout(time.Minute)).(*circuitBreakerExporter)
+	e.now = func() time.Time { return *now }
+	return e
+}
+
+func TestCircuitBreakerExporter(t *testing.T) {
+	ctx := context.Background()
+	errBackend := errors.New("backend down")
+	next := &failingExporter{err: errBackend}
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	e := newTestCircuitBreaker(next, &now)
+
+	// Closed: the failures are passed through until the threshold.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, errBackend, e.ExportSpans(ctx, nil))
+	}
+	assert.Equal(t, circuitOpen, e.state)
+	assert.Equal(t, 3, next.exports)
+
+	// Open: the exports fail fast.
+	now = now.Add(59 * time.Second)
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 3, next.exports)
+
+	// Half-open: a failed trial opens the circuit again.
+	now = now.Add(time.Second)
+	assert.Equal(t, errBackend, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 4, next.exports)
+	assert.Equal(t, circuitOpen, e.state)
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+
+	// Half-open: a successful trial closes the circuit.
+	now = now.Add(time.Minute)
+	next.err = nil
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.Equal(t, circuitClosed, e.state)
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 6, next.exports)
+}
+
+func TestCircuitBreakerExporterSuccessResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	next := &failingExporter{err: errors.New("backend down")}
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	e := newTestCircuitBreaker(next, &now)
+
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	next.err = nil
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	next.err = errors.New("backend down")
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	assert.Equal(t, circuitClosed, e.state)
+}
+
+// blockingExporter blocks its exports until release is closed.
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExporter) ExportSpans(context.Context, []*SpanSnapshot) error {
+	e.started <- struct{}{}
+	<-e.release
+	return nil
+}
+
+func (e *blockingExporter) Shutdown(context.Context) error { return nil }
+
+func TestCircuitBreakerExporterHalfOpenSingleTrial(t *testing.T) {
+	ctx := context.Background()
+	next := &blockingExporter{started: make(chan struct{}), release: make(chan struct{})}
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	e := newTestCircuitBreaker(next, &now)
+	e.state = circuitOpen
+	e.openedAt = now.Add(-time.Minute)
+
+	done := make(chan error)
+	go func() { done <- e.ExportSpans(ctx, nil) }()
+	<-next.started
+	// The concurrent exports fail while the trial is in progress.
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	close(next.release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, circuitClosed, e.state)
+}
+
+func TestCircuitBreakerExporterDefaults(t *testing.T) {
+	e := NewCircuitBreakerExporter(&failingExporter{}, WithFailureThreshold(-1), WithResetTimeout(0)).(*circuitBreakerExporter)
+	assert.Equal(t, CircuitBreakerOptions{
+		FailureThreshold: DefaultFailureThreshold,
+		ResetTimeout:     DefaultResetTimeout,
+	}, e.o)
+}
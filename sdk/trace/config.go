@@ -30,6 +30,33 @@ type SpanLimits struct {
 
 	// AttributePerLinkCountLimit is the maximum allowed attribute per span link count.
 	AttributePerLinkCountLimit int
+
+	// AttributeValueLengthLimit is the maximum allowed length, in
+	// characters, of string attribute values of a span, its events, and its
+	// links. Longer values are truncated. If not positive, values are not
+	// truncated.
+	AttributeValueLengthLimit int
+
+	// AttributeTotalBytesLimit is the maximum allowed sum of the sizes, in
+	// bytes, of the attribute values of a span. The attributes that would
+	// exceed it once added are dropped. If not positive, the sum is not
+	// limited.
+	AttributeTotalBytesLimit int
+
+	// AttributeKeyLengthLimit is the maximum allowed length, in characters,
+	// of the attribute keys of a span, its events, and its links. Longer
+	// keys are truncated, or their attributes are dropped if
+	// DropLongAttributeKeys is true. If not positive, keys are not limited.
+	//
+	// Truncated keys can collide: the attribute set last overrides the
+	// others.
+	AttributeKeyLengthLimit int
+
+	// DropLongAttributeKeys selects to drop the attributes whose keys are
+	// longer than AttributeKeyLengthLimit, instead of truncating their keys.
+	// The dropped attributes are accounted for in the dropped attribute
+	// count of their span, event, or link.
+	DropLongAttributeKeys bool
 }
 
 func (sl *SpanLimits) ensureDefault() {
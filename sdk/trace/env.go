@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Environment variable names the SpanLimits of a TracerProvider can be
+// assigned with.
+const (
+	envSpanAttributeCountLimit       = "OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT"
+	envSpanEventCountLimit           = "OTEL_SPAN_EVENT_COUNT_LIMIT"
+	envSpanLinkCountLimit            = "OTEL_SPAN_LINK_COUNT_LIMIT"
+	envSpanAttributeValueLengthLimit = "OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+)
+
+// fromEnv sets all limits of sl that are not set, i.e. not positive, to the
+// value of their environment variable. Invalid values are reported to the
+// global error handler and ignored.
+func (sl *SpanLimits) fromEnv() {
+	for _, l := range []struct {
+		env   string
+		limit *int
+	}{
+		{envSpanAttributeCountLimit, &sl.AttributeCountLimit},
+		{envSpanEventCountLimit, &sl.EventCountLimit},
+		{envSpanLinkCountLimit, &sl.LinkCountLimit},
+		{envSpanAttributeValueLengthLimit, &sl.AttributeValueLengthLimit},
+	} {
+		if *l.limit > 0 {
+			continue
+		}
+		v := strings.TrimSpace(os.Getenv(l.env))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			otel.Handle(fmt.Errorf("invalid %s value %q: must be a positive integer", l.env, v))
+			continue
+		}
+		*l.limit = n
+	}
+}
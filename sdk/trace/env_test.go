@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+)
+
+func TestSpanLimitsFromEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		envars map[string]string
+		limits SpanLimits
+		want   SpanLimits
+		errs   int
+	}{
+		{
+			name: "not set",
+			want: SpanLimits{},
+		},
+		{
+			name: "set",
+			envars: map[string]string{
+				envSpanAttributeCountLimit:       "1",
+				envSpanEventCountLimit:           "2",
+				envSpanLinkCountLimit:            " 3 ",
+				envSpanAttributeValueLengthLimit: "4",
+			},
+			want: SpanLimits{
+				AttributeCountLimit:       1,
+				EventCountLimit:           2,
+				LinkCountLimit:            3,
+				AttributeValueLengthLimit: 4,
+			},
+		},
+		{
+			name: "explicit limits take precedence",
+			envars: map[string]string{
+				envSpanAttributeCountLimit: "1",
+				envSpanEventCountLimit:     "2",
+			},
+			limits: SpanLimits{AttributeCountLimit: 10},
+			want:   SpanLimits{AttributeCountLimit: 10, EventCountLimit: 2},
+		},
+		{
+			name: "invalid",
+			envars: map[string]string{
+				envSpanAttributeCountLimit: "many",
+				envSpanEventCountLimit:     "-1",
+				envSpanLinkCountLimit:      "3",
+			},
+			want: SpanLimits{LinkCountLimit: 3},
+			errs: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			envars := map[string]string{
+				envSpanAttributeCountLimit:       "",
+				envSpanEventCountLimit:           "",
+				envSpanLinkCountLimit:            "",
+				envSpanAttributeValueLengthLimit: "",
+			}
+			for k, v := range test.envars {
+				envars[k] = v
+			}
+			store, err := ottest.SetEnvVariables(envars)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, store.Restore()) }()
+			handler.Reset()
+
+			sl := test.limits
+			sl.fromEnv()
+			assert.Equal(t, test.want, sl)
+			assert.Len(t, handler.errs, test.errs)
+		})
+	}
+}
+
+func TestTracerProviderSpanLimitsFromEnv(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envSpanAttributeCountLimit:       "invalid",
+		envSpanLinkCountLimit:            "3",
+		envSpanAttributeValueLengthLimit: "4",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{EventCountLimit: 1}))
+	assert.Equal(t, SpanLimits{
+		AttributeCountLimit:         DefaultAttributeCountLimit,
+		EventCountLimit:             1,
+		LinkCountLimit:              3,
+		AttributePerEventCountLimit: DefaultAttributePerEventCountLimit,
+		AttributePerLinkCountLimit:  DefaultAttributePerLinkCountLimit,
+		AttributeValueLengthLimit:   4,
+	}, tp.spanLimits)
+}
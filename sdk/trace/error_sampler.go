@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// NewErrorSampler returns a SpanProcessor that passes only the ended spans
+// that failed to next. A span failed if its status code is codes.Error or
+// if it recorded an exception event, e.g. with RecordError. All other spans
+// are dropped.
+//
+// Contrary to a Sampler, the decision is made once the span has ended and
+// its final status is known. Spans dropped by a Sampler are never seen by
+// this SpanProcessor.
+func NewErrorSampler(next SpanProcessor) SpanProcessor {
+	return filterProcessor{next: next, keep: failed}
+}
+
+// failed returns if s has an error status or recorded an exception.
+func failed(s ReadOnlySpan) bool {
+	if s.StatusCode() == codes.Error {
+		return true
+	}
+	for _, e := range s.Events() {
+		if e.Name == semconv.ExceptionEventName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestErrorSampler(t *testing.T) {
+	te := &testExporter{}
+	es := sdktrace.NewErrorSampler(sdktrace.NewSimpleSpanProcessor(te))
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(es)).Tracer("ErrorSampler")
+	ctx := context.Background()
+
+	_, span := tr.Start(ctx, "ok")
+	span.SetStatus(codes.Ok, "")
+	span.End()
+
+	_, span = tr.Start(ctx, "unset")
+	span.AddEvent("not an exception")
+	span.End()
+
+	_, span = tr.Start(ctx, "error status")
+	span.SetStatus(codes.Error, "failed")
+	span.End()
+
+	_, span = tr.Start(ctx, "exception")
+	span.RecordError(errors.New("failed"))
+	span.End()
+
+	require.Len(t, te.spans, 2)
+	assert.Equal(t, "error status", te.spans[0].Name)
+	assert.Equal(t, "exception", te.spans[1].Name)
+
+	require.NoError(t, es.Shutdown(ctx))
+	assert.True(t, te.shutdown)
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EventCountKey is the attribute key used to record the number of events
+// that were collapsed into a single summary event.
+const EventCountKey = attribute.Key("event.count")
+
+// EventCollapseRule defines which events are considered identical by the
+// event summary SpanProcessor.
+type EventCollapseRule int
+
+const (
+	// CollapseByName collapses all events with the same name.
+	CollapseByName EventCollapseRule = iota
+	// CollapseByNameAndAttributes collapses all events with the same name
+	// and the same set of attributes.
+	CollapseByNameAndAttributes
+)
+
+// eventSummarySpanProcessor is a SpanProcessor that collapses identical
+// events of an ended span into a single event before passing the span to
+// the next SpanProcessor.
+type eventSummarySpanProcessor struct {
+	next SpanProcessor
+	rule EventCollapseRule
+}
+
+var _ SpanProcessor = eventSummarySpanProcessor{}
+
+// NewEventSummarySpanProcessor returns a SpanProcessor that collapses all
+// events of an ended span that are identical according to rule into a single
+// event before passing the span to next.
+//
+// The summary event retains the time, attributes, and dropped attribute
+// count of the first event it replaces and, if more than one event was
+// collapsed, an EventCountKey attribute holding the number of collapsed
+// events. An EventCountKey attribute of the first event is replaced. Events
+// are otherwise kept in their original order.
+// Collapsed events are not counted as dropped events.
+func NewEventSummarySpanProcessor(next SpanProcessor, rule EventCollapseRule) SpanProcessor {
+	return eventSummarySpanProcessor{next: next, rule: rule}
+}
+
+// OnStart passes s to the next SpanProcessor.
+func (p eventSummarySpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s with its events summarized to the next SpanProcessor.
+func (p eventSummarySpanProcessor) OnEnd(s ReadOnlySpan) {
+	events := s.Events()
+	if len(events) < 2 {
+		p.next.OnEnd(s)
+		return
+	}
+
+	ss := s.Snapshot()
+	ss.MessageEvents = p.summarize(events)
+	p.next.OnEnd(newSnapshotSpan(s, ss))
+}
+
+// Shutdown shuts down the next SpanProcessor.
+func (p eventSummarySpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next SpanProcessor.
+func (p eventSummarySpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+type eventKey struct {
+	name  string
+	attrs attribute.Distinct
+}
+
+func (p eventSummarySpanProcessor) summarize(events []Event) []Event {
+	idx := make(map[eventKey]int, len(events))
+	counts := make([]int, 0, len(events))
+	summary := make([]Event, 0, len(events))
+	for _, e := range events {
+		k := eventKey{name: e.Name}
+		if p.rule == CollapseByNameAndAttributes {
+			set := attribute.NewSet(e.Attributes...)
+			k.attrs = set.Equivalent()
+		}
+		if i, ok := idx[k]; ok {
+			counts[i]++
+			continue
+		}
+		idx[k] = len(summary)
+		counts = append(counts, 1)
+		summary = append(summary, e)
+	}
+
+	for i, n := range counts {
+		if n < 2 {
+			continue
+		}
+		attrs := make([]attribute.KeyValue, 0, len(summary[i].Attributes)+1)
+		for _, kv := range summary[i].Attributes {
+			if kv.Key != EventCountKey {
+				attrs = append(attrs, kv)
+			}
+		}
+		summary[i].Attributes = append(attrs, EventCountKey.Int(n))
+	}
+	return summary
+}
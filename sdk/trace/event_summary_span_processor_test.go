@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestEventSummarySpanProcessorCountAttribute(t *testing.T) {
+	te := &testExporter{}
+	esp := sdktrace.NewEventSummarySpanProcessor(sdktrace.NewSimpleSpanProcessor(te), sdktrace.CollapseByName)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(esp))
+
+	count := attribute.Int("count", 7)
+	_, span := tp.Tracer("EventSummary").Start(context.Background(), "span")
+	span.AddEvent("retry", trace.WithAttributes(count, sdktrace.EventCountKey.Int(1)))
+	span.AddEvent("retry")
+	span.End()
+
+	require.Len(t, te.spans, 1)
+	require.Len(t, te.spans[0].MessageEvents, 1)
+	// A user "count" attribute is kept, an EventCountKey one is replaced.
+	want := []attribute.KeyValue{count, sdktrace.EventCountKey.Int(2)}
+	assert.Equal(t, want, te.spans[0].MessageEvents[0].Attributes)
+}
+
+func TestEventSummarySpanProcessor(t *testing.T) {
+	a := attribute.String("k", "a")
+	b := attribute.String("k", "b")
+
+	testcases := []struct {
+		name string
+		rule sdktrace.EventCollapseRule
+		want []sdktrace.Event
+	}{
+		{
+			name: "by name",
+			rule: sdktrace.CollapseByName,
+			want: []sdktrace.Event{
+				{Name: "retry", Attributes: []attribute.KeyValue{a, sdktrace.EventCountKey.Int(3)}},
+				{Name: "done"},
+			},
+		},
+		{
+			name: "by name and attributes",
+			rule: sdktrace.CollapseByNameAndAttributes,
+			want: []sdktrace.Event{
+				{Name: "retry", Attributes: []attribute.KeyValue{a, sdktrace.EventCountKey.Int(2)}},
+				{Name: "retry", Attributes: []attribute.KeyValue{b}},
+				{Name: "done"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			te := &testExporter{}
+			esp := sdktrace.NewEventSummarySpanProcessor(sdktrace.NewSimpleSpanProcessor(te), tc.rule)
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(esp))
+
+			_, span := tp.Tracer("EventSummary").Start(context.Background(), "span")
+			span.AddEvent("retry", trace.WithAttributes(a))
+			span.AddEvent("retry", trace.WithAttributes(b))
+			span.AddEvent("retry", trace.WithAttributes(a))
+			span.AddEvent("done")
+			span.End()
+
+			require.Len(t, te.spans, 1)
+			got := te.spans[0].MessageEvents
+			require.Len(t, got, len(tc.want))
+			for i, e := range got {
+				assert.Equal(t, tc.want[i].Name, e.Name)
+				assert.Equal(t, tc.want[i].Attributes, e.Attributes)
+			}
+			assert.Equal(t, 0, te.spans[0].DroppedMessageEventCount)
+		})
+	}
+}
+
+func TestEventSummarySpanProcessorKeepsDroppedCount(t *testing.T) {
+	te := &testExporter{}
+	esp := sdktrace.NewEventSummarySpanProcessor(sdktrace.NewSimpleSpanProcessor(te), sdktrace.CollapseByName)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(esp),
+		sdktrace.WithSpanLimits(sdktrace.SpanLimits{EventCountLimit: 2}),
+	)
+
+	_, span := tp.Tracer("EventSummary").Start(context.Background(), "span")
+	for i := 0; i < 5; i++ {
+		span.AddEvent("retry")
+	}
+	span.End()
+
+	require.Len(t, te.spans, 1)
+	require.Len(t, te.spans[0].MessageEvents, 1)
+	assert.Equal(t, []attribute.KeyValue{sdktrace.EventCountKey.Int(2)}, te.spans[0].MessageEvents[0].Attributes)
+	assert.Equal(t, 3, te.spans[0].DroppedMessageEventCount)
+}
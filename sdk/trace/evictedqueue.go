@@ -20,15 +20,24 @@ type evictedQueue struct {
 	droppedCount int
 }
 
+// newEvictedQueue returns an evictedQueue holding up to capacity values. The
+// queue is only allocated once a value is added.
 func newEvictedQueue(capacity int) *evictedQueue {
 	eq := &evictedQueue{
 		capacity: capacity,
-		queue:    make([]interface{}, 0),
 	}
 
 	return eq
 }
 
+// len returns the number of values in eq. It is safe to call on a nil eq.
+func (eq *evictedQueue) len() int {
+	if eq == nil {
+		return 0
+	}
+	return len(eq.queue)
+}
+
 func (eq *evictedQueue) add(value interface{}) {
 	if len(eq.queue) == eq.capacity {
 		eq.queue = eq.queue[1:]
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
+)
+
+const (
+	// ExportDurationInstrumentName is the name of the ValueRecorder of the
+	// exporters returned by NewExportDurationExporter.
+	ExportDurationInstrumentName = "otel.sdk.span.export.duration"
+
+	// ExportSuccessKey is the label of the export durations telling if the
+	// export succeeded.
+	ExportSuccessKey = attribute.Key("otel.sdk.span.export.success")
+)
+
+// exportDurationExporter is a SpanExporter recording the duration of the
+// exports of the next SpanExporter.
+type exportDurationExporter struct {
+	next     SpanExporter
+	duration metric.Float64ValueRecorder
+}
+
+var _ SpanExporter = (*exportDurationExporter)(nil)
+
+// NewExportDurationExporter returns a SpanExporter passing the exports to
+// next and recording their durations, in milliseconds, with a ValueRecorder
+// named ExportDurationInstrumentName created with meter. Each duration is
+// labeled with ExportSuccessKey, true if the export succeeded and false
+// otherwise. This gives operators insight into the performance of next.
+//
+// Recording the durations is opt-in: the exporters not wrapped by this
+// function have no overhead. An error is returned if the ValueRecorder
+// cannot be created.
+func NewExportDurationExporter(next SpanExporter, meter metric.Meter) (SpanExporter, error) {
+	duration, err := meter.NewFloat64ValueRecorder(
+		ExportDurationInstrumentName,
+		metric.WithDescription("Duration of the span exports"),
+		metric.WithUnit(unit.Milliseconds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &exportDurationExporter{next: next, duration: duration}, nil
+}
+
+// ExportSpans passes ss to the next SpanExporter and records the duration of
+// the export.
+func (e *exportDurationExporter) ExportSpans(ctx context.Context, ss []*SpanSnapshot) error {
+	start := time.Now()
+	err := e.next.ExportSpans(ctx, ss)
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+	e.duration.Record(ctx, elapsed, ExportSuccessKey.Bool(err == nil))
+	return err
+}
+
+// Shutdown shuts down the next SpanExporter.
+func (e *exportDurationExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/oteltest"
+)
+
+func TestExportDurationExporter(t *testing.T) {
+	impl, meter := oteltest.NewMeter()
+	next := &failingExporter{}
+	e, err := NewExportDurationExporter(next, meter)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	next.err = errors.New("export failed")
+	assert.Equal(t, next.err, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 2, next.exports)
+
+	measured := oteltest.AsStructs(impl.MeasurementBatches)
+	require.Len(t, measured, 2)
+	for i, success := range []bool{true, false} {
+		assert.Equal(t, ExportDurationInstrumentName, measured[i].Name)
+		assert.Equal(t, map[attribute.Key]attribute.Value{
+			ExportSuccessKey: attribute.BoolValue(success),
+		}, measured[i].Labels)
+		assert.GreaterOrEqual(t, measured[i].Number.AsFloat64(), 0.0)
+	}
+	assert.NoError(t, e.Shutdown(ctx))
+}
@@ -25,8 +25,13 @@ import (
 )
 
 // IDGenerator allows custom generators for TraceID and SpanID.
+//
+// An IDGenerator is used by all the Tracers of a TracerProvider, it needs to
+// be safe to be called concurrently.
 type IDGenerator interface {
+	// NewIDs returns a new trace ID and span ID for a root span.
 	NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID)
+	// NewSpanID returns a new span ID for a span of the trace traceID.
 	NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID
 }
 
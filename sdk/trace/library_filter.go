@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "strings"
+
+// LibraryMatch defines how the library filter SpanProcessor matches the
+// names of instrumentation libraries.
+type LibraryMatch int
+
+const (
+	// MatchExact matches the instrumentation libraries named exactly as a
+	// denied name.
+	MatchExact LibraryMatch = iota
+	// MatchPrefix matches the instrumentation libraries with a name that
+	// starts with a denied name.
+	MatchPrefix
+)
+
+// NewLibraryFilter returns a SpanProcessor that drops the ended spans
+// created by an instrumentation library with a name matching one of the
+// denied names according to match. All other spans are passed to next.
+//
+// This allows silencing instrumentation that cannot be reconfigured.
+func NewLibraryFilter(next SpanProcessor, match LibraryMatch, denied ...string) SpanProcessor {
+	if match == MatchPrefix {
+		prefixes := append([]string(nil), denied...)
+		return filterProcessor{next: next, keep: func(s ReadOnlySpan) bool {
+			name := s.InstrumentationLibrary().Name
+			for _, p := range prefixes {
+				if strings.HasPrefix(name, p) {
+					return false
+				}
+			}
+			return true
+		}}
+	}
+
+	names := make(map[string]struct{}, len(denied))
+	for _, name := range denied {
+		names[name] = struct{}{}
+	}
+	return filterProcessor{next: next, keep: func(s ReadOnlySpan) bool {
+		_, ok := names[s.InstrumentationLibrary().Name]
+		return !ok
+	}}
+}
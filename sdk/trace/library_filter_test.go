@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLibraryFilter(t *testing.T) {
+	libraries := []string{
+		"github.com/noisy/lib",
+		"github.com/noisy/lib/sub",
+		"github.com/noisy/library",
+		"github.com/quiet/lib",
+	}
+	tests := []struct {
+		name   string
+		match  sdktrace.LibraryMatch
+		denied []string
+		want   []string
+	}{
+		{
+			name:   "no denied",
+			match:  sdktrace.MatchExact,
+			denied: nil,
+			want:   libraries,
+		},
+		{
+			name:   "exact",
+			match:  sdktrace.MatchExact,
+			denied: []string{"github.com/noisy/lib", "github.com/unused"},
+			want:   []string{"github.com/noisy/lib/sub", "github.com/noisy/library", "github.com/quiet/lib"},
+		},
+		{
+			name:   "prefix",
+			match:  sdktrace.MatchPrefix,
+			denied: []string{"github.com/noisy/lib/"},
+			want:   []string{"github.com/noisy/lib", "github.com/noisy/library", "github.com/quiet/lib"},
+		},
+		{
+			name:   "prefix multiple",
+			match:  sdktrace.MatchPrefix,
+			denied: []string{"github.com/noisy", "github.com/quiet/lib"},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := &testExporter{}
+			sp := sdktrace.NewLibraryFilter(sdktrace.NewSimpleSpanProcessor(te), tt.match, tt.denied...)
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp))
+			for _, lib := range libraries {
+				_, span := tp.Tracer(lib).Start(context.Background(), "span")
+				span.End()
+			}
+
+			var got []string
+			for _, s := range te.spans {
+				got = append(got, s.InstrumentationLibrary.Name)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, tp.Shutdown(context.Background()))
+			assert.True(t, te.shutdown)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"strconv"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+)
+
+// LogFormat is the format of the records written by a log SpanProcessor.
+type LogFormat int
+
+const (
+	// LogFormatText writes records as space separated key=value pairs.
+	LogFormatText LogFormat = iota
+	// LogFormatJSON writes records as JSON objects.
+	LogFormatJSON
+)
+
+// logSpanProcessor is a SpanProcessor that writes a log record for every
+// ended span.
+type logSpanProcessor struct {
+	logger  *log.Logger
+	format  LogFormat
+	stopped int32
+}
+
+var _ SpanProcessor = (*logSpanProcessor)(nil)
+
+// NewLogSpanProcessor returns a SpanProcessor that writes the trace ID, span
+// ID, name, and duration of every ended and sampled span as a record to
+// logger using format. This allows correlating application logs and traces
+// when no exporter is available.
+//
+// Spans that are not sampled are never logged, regardless of the sampler
+// that made the decision.
+//
+// If logger is nil, the standard logger of the log package is used.
+func NewLogSpanProcessor(logger *log.Logger, format LogFormat) SpanProcessor {
+	if logger == nil {
+		logger = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+	return &logSpanProcessor{logger: logger, format: format}
+}
+
+// NewLogWriterSpanProcessor returns a SpanProcessor that writes the same
+// records as NewLogSpanProcessor to w using format, one record per line and
+// without any prefix. Spans that are not sampled are never logged.
+//
+// If w is nil, the output of the standard logger of the log package is used.
+func NewLogWriterSpanProcessor(w io.Writer, format LogFormat) SpanProcessor {
+	if w == nil {
+		w = log.Writer()
+	}
+	return NewLogSpanProcessor(log.New(w, "", 0), format)
+}
+
+// OnStart does nothing.
+func (lsp *logSpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnd writes a log record for s.
+func (lsp *logSpanProcessor) OnEnd(s ReadOnlySpan) {
+	if atomic.LoadInt32(&lsp.stopped) != 0 {
+		return
+	}
+	sc := s.SpanContext()
+	if !sc.IsSampled() {
+		return
+	}
+
+	traceID := sc.TraceID().String()
+	spanID := sc.SpanID().String()
+	duration := s.EndTime().Sub(s.StartTime()).String()
+
+	switch lsp.format {
+	case LogFormatJSON:
+		rec, err := json.Marshal(struct {
+			TraceID  string `json:"trace_id"`
+			SpanID   string `json:"span_id"`
+			Name     string `json:"name"`
+			Duration string `json:"duration"`
+		}{traceID, spanID, s.Name(), duration})
+		if err != nil {
+			otel.Handle(err)
+			return
+		}
+		lsp.logger.Print(string(rec))
+	default:
+		lsp.logger.Printf(
+			"trace_id=%s span_id=%s name=%s duration=%s",
+			traceID, spanID, strconv.Quote(s.Name()), duration,
+		)
+	}
+}
+
+// Shutdown stops the processor from writing any further records.
+func (lsp *logSpanProcessor) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&lsp.stopped, 1)
+	return ctx.Err()
+}
+
+// ForceFlush does nothing as records are written synchronously.
+func (lsp *logSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLogSpanProcessor(t *testing.T) {
+	start := time.Now()
+	end := start.Add(1500 * time.Millisecond)
+
+	testcases := []struct {
+		format sdktrace.LogFormat
+		want   func(sc trace.SpanContext) string
+	}{
+		{
+			format: sdktrace.LogFormatText,
+			want: func(sc trace.SpanContext) string {
+				return fmt.Sprintf("trace_id=%s span_id=%s name=\"span 0\" duration=1.5s\n", sc.TraceID(), sc.SpanID())
+			},
+		},
+		{
+			format: sdktrace.LogFormatJSON,
+			want: func(sc trace.SpanContext) string {
+				return fmt.Sprintf(`{"trace_id":"%s","span_id":"%s","name":"span 0","duration":"1.5s"}`+"\n", sc.TraceID(), sc.SpanID())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+		lsp := sdktrace.NewLogSpanProcessor(log.New(&buf, "", 0), tc.format)
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(lsp))
+
+		_, span := tp.Tracer("Log").Start(context.Background(), "span 0", trace.WithTimestamp(start))
+		span.End(trace.WithTimestamp(end))
+		assert.Equal(t, tc.want(span.SpanContext()), buf.String())
+	}
+}
+
+func TestLogWriterSpanProcessor(t *testing.T) {
+	var buf bytes.Buffer
+	lsp := sdktrace.NewLogWriterSpanProcessor(&buf, sdktrace.LogFormatJSON)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(lsp))
+	tr := tp.Tracer("Log")
+
+	start := time.Now()
+	_, first := tr.Start(context.Background(), "span 0", trace.WithTimestamp(start))
+	first.End(trace.WithTimestamp(start.Add(time.Second)))
+	_, second := tr.Start(context.Background(), "span 1", trace.WithTimestamp(start))
+	second.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	want := fmt.Sprintf(`{"trace_id":"%s","span_id":"%s","name":"span 0","duration":"1s"}`+"\n", first.SpanContext().TraceID(), first.SpanContext().SpanID()) +
+		fmt.Sprintf(`{"trace_id":"%s","span_id":"%s","name":"span 1","duration":"2s"}`+"\n", second.SpanContext().TraceID(), second.SpanContext().SpanID())
+	assert.Equal(t, want, buf.String())
+}
+
+func TestLogSpanProcessorIgnoresSpans(t *testing.T) {
+	var buf bytes.Buffer
+	lsp := sdktrace.NewLogSpanProcessor(log.New(&buf, "", 0), sdktrace.LogFormatText)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(lsp),
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+	)
+	tr := tp.Tracer("Log")
+
+	_, span := tr.Start(context.Background(), "not sampled")
+	span.End()
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, lsp.Shutdown(context.Background()))
+	tp = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(lsp))
+	_, span = tp.Tracer("Log").Start(context.Background(), "after shutdown")
+	span.End()
+	assert.Empty(t, buf.String())
+}
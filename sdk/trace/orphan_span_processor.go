@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultMaxOrphanTraces is the default maximum number of traces an orphan
+// span processor tracks.
+const defaultMaxOrphanTraces = 1024
+
+// orphanSpanProcessor is a SpanProcessor that drops the ended spans whose
+// parent was not sampled instead of passing them to the next SpanProcessor.
+type orphanSpanProcessor struct {
+	next      SpanProcessor
+	maxTraces int
+
+	mu sync.Mutex
+	// traces holds the elements of order by trace ID.
+	traces map[trace.TraceID]*list.Element
+	// order holds the *unsampledTrace of the tracked traces, the most
+	// recently used first.
+	order *list.List
+}
+
+// unsampledTrace holds the unsampled spans of a trace.
+type unsampledTrace struct {
+	traceID trace.TraceID
+	// spans holds the IDs of the spans of the trace that are not sampled or
+	// descend from a span that is not sampled.
+	spans map[trace.SpanID]struct{}
+}
+
+var _ SpanProcessor = (*orphanSpanProcessor)(nil)
+
+// NewOrphanSpanProcessor returns a SpanProcessor that drops the ended spans
+// descending from a span that was not sampled instead of passing them to
+// next. Such spans are recorded and sampled when the Sampler of their tracer
+// ignores the decision taken for their parent, but their parent is not
+// exported, they would be exported as orphans. Spans that are not sampled
+// themselves are still passed to next.
+//
+// The processor tracks the spans of the traces having a span that is not
+// sampled, at most maxTraces traces, or 1024 if maxTraces is not positive,
+// are tracked at a time. When the limit is reached, the least recently used
+// trace is forgotten: a span whose parent is sampled but descends from a
+// span of a forgotten trace that is not sampled is passed to next.
+func NewOrphanSpanProcessor(next SpanProcessor, maxTraces int) SpanProcessor {
+	if maxTraces <= 0 {
+		maxTraces = defaultMaxOrphanTraces
+	}
+	return &orphanSpanProcessor{
+		next:      next,
+		maxTraces: maxTraces,
+		traces:    make(map[trace.TraceID]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// OnStart tracks s if it is not sampled or is an orphan and passes s to the
+// next SpanProcessor.
+func (p *orphanSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	sc := s.SpanContext()
+	p.mu.Lock()
+	if !sc.IsSampled() || p.isOrphan(s) {
+		p.track(sc)
+	}
+	p.mu.Unlock()
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s to the next SpanProcessor unless s is an orphan.
+func (p *orphanSpanProcessor) OnEnd(s ReadOnlySpan) {
+	p.mu.Lock()
+	orphan := p.isOrphan(s)
+	p.mu.Unlock()
+	if orphan {
+		return
+	}
+	p.next.OnEnd(s)
+}
+
+// isOrphan returns if s descends from a span that is not sampled. It needs to
+// be called with p.mu held.
+func (p *orphanSpanProcessor) isOrphan(s ReadOnlySpan) bool {
+	psc := s.Parent()
+	if !psc.IsValid() {
+		return false
+	}
+	if !psc.IsSampled() {
+		return true
+	}
+	e, ok := p.traces[psc.TraceID()]
+	if !ok {
+		return false
+	}
+	p.order.MoveToFront(e)
+	_, ok = e.Value.(*unsampledTrace).spans[psc.SpanID()]
+	return ok
+}
+
+// track records that the span of sc is not sampled or is an orphan. It needs
+// to be called with p.mu held.
+func (p *orphanSpanProcessor) track(sc trace.SpanContext) {
+	e, ok := p.traces[sc.TraceID()]
+	if ok {
+		p.order.MoveToFront(e)
+	} else {
+		if p.order.Len() >= p.maxTraces {
+			oldest := p.order.Back()
+			p.order.Remove(oldest)
+			delete(p.traces, oldest.Value.(*unsampledTrace).traceID)
+		}
+		e = p.order.PushFront(&unsampledTrace{
+			traceID: sc.TraceID(),
+			spans:   make(map[trace.SpanID]struct{}),
+		})
+		p.traces[sc.TraceID()] = e
+	}
+	e.Value.(*unsampledTrace).spans[sc.SpanID()] = struct{}{}
+}
+
+// Shutdown shuts down the next SpanProcessor.
+func (p *orphanSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next SpanProcessor.
+func (p *orphanSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *orphanSpanProcessor) exportCounts() exportCounts { return exportCountsOf(p.next) }
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// nameSampler samples the spans whatever their parent, except the ones named
+// drop, which are only recorded.
+type nameSampler struct {
+	drop string
+}
+
+func (s nameSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if p.Name == s.drop {
+		return sdktrace.SamplingResult{Decision: sdktrace.RecordOnly}
+	}
+	return sdktrace.SamplingResult{Decision: sdktrace.RecordAndSample}
+}
+
+func (s nameSampler) Description() string { return "nameSampler" }
+
+func spanNames(ss []*sdktrace.SpanSnapshot) []string {
+	names := make([]string, 0, len(ss))
+	for _, s := range ss {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestOrphanSpanProcessor(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(nameSampler{drop: "dropped"}),
+		sdktrace.WithSpanProcessor(sdktrace.NewOrphanSpanProcessor(sdktrace.NewSimpleSpanProcessor(te), 0)),
+	)
+	tr := tp.Tracer("OrphanSpanProcessor")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	dctx, dropped := tr.Start(ctx, "dropped")
+	cctx, child := tr.Start(dctx, "child")
+	_, grandchild := tr.Start(cctx, "grandchild")
+	_, sibling := tr.Start(ctx, "sibling")
+	grandchild.End()
+	child.End()
+	dropped.End()
+	sibling.End()
+	root.End()
+
+	assert.Equal(t, []string{"sibling", "root"}, spanNames(te.spans))
+}
+
+func TestOrphanSpanProcessorEviction(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(nameSampler{drop: "dropped"}),
+		sdktrace.WithSpanProcessor(sdktrace.NewOrphanSpanProcessor(sdktrace.NewSimpleSpanProcessor(te), 1)),
+	)
+	tr := tp.Tracer("OrphanSpanProcessor")
+
+	ctx, _ := tr.Start(context.Background(), "dropped")
+	cctx, child := tr.Start(ctx, "child")
+	// Tracking a second trace evicts the first one.
+	_, other := tr.Start(context.Background(), "dropped")
+	_, grandchild := tr.Start(cctx, "grandchild")
+	grandchild.End()
+	child.End()
+	other.End()
+
+	// The grandchild is not known to descend from an unsampled span anymore.
+	require.Len(t, te.spans, 1)
+	assert.Equal(t, "grandchild", te.spans[0].Name)
+}
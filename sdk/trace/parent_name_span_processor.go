@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ParentNameKey is the attribute key used to record the name of the parent
+// span of a span.
+const ParentNameKey = attribute.Key("parent.name")
+
+// parentNameSpanProcessor is a SpanProcessor that records the name of the
+// parent span of a started span as an attribute of the span.
+type parentNameSpanProcessor struct{}
+
+var _ SpanProcessor = parentNameSpanProcessor{}
+
+// NewParentNameSpanProcessor returns a SpanProcessor that records the name
+// of the parent span of every started span as a ParentNameKey attribute of
+// the span. This helps reconstructing span trees from exported spans by
+// hand.
+//
+// The parent span is looked up in the context the span is started with.
+// Root spans and spans with a remote parent, with a parent not created by
+// this SDK, or with a parent that is not recorded, and so has no name, do not
+// get the attribute.
+func NewParentNameSpanProcessor() SpanProcessor {
+	return parentNameSpanProcessor{}
+}
+
+// OnStart records the name of the parent span of s found in parent.
+func (parentNameSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	psc := s.Parent()
+	if !psc.IsValid() || psc.IsRemote() {
+		return
+	}
+	p, ok := trace.SpanFromContext(parent).(ReadOnlySpan)
+	if !ok || !p.SpanContext().Equal(psc) || !p.IsRecording() || p.Name() == "" {
+		return
+	}
+	s.SetAttributes(ParentNameKey.String(p.Name()))
+}
+
+// OnEnd does nothing.
+func (parentNameSpanProcessor) OnEnd(ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (parentNameSpanProcessor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush does nothing.
+func (parentNameSpanProcessor) ForceFlush(context.Context) error { return nil }
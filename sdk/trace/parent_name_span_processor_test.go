@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestParentNameSpanProcessor(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewParentNameSpanProcessor()),
+		sdktrace.WithSyncer(te),
+	)
+	tr := tp.Tracer("ParentNameSpanProcessor")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	remote := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	_, remoteChild := tr.Start(remote, "remote child")
+	remoteChild.End()
+
+	require.Len(t, te.spans, 3)
+	assert.Equal(t, "child", te.spans[0].Name)
+	assert.Equal(t, []attribute.KeyValue{sdktrace.ParentNameKey.String("root")}, te.spans[0].Attributes)
+	assert.Equal(t, "root", te.spans[1].Name)
+	assert.Empty(t, te.spans[1].Attributes)
+	assert.Equal(t, "remote child", te.spans[2].Name)
+	assert.Empty(t, te.spans[2].Attributes)
+}
+
+func TestParentNameSpanProcessorNotRecordedParent(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+		sdktrace.WithSpanProcessor(sdktrace.NewParentNameSpanProcessor()),
+		sdktrace.WithSyncer(te),
+	)
+	tr := tp.Tracer("ParentNameSpanProcessor")
+
+	ctx, parent := tr.Start(context.Background(), "parent")
+	require.False(t, parent.IsRecording())
+	_, child := tr.Start(ctx, "child", trace.WithSpanSampled(true))
+	child.End()
+	parent.End()
+
+	require.Len(t, te.spans, 1)
+	assert.Equal(t, "child", te.spans[0].Name)
+	assert.Empty(t, te.spans[0].Attributes)
+}
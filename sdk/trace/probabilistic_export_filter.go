@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// NewProbabilisticExportFilter returns a SpanProcessor that passes the given
+// fraction of the ended spans to next and drops all others. Contrary to a
+// Sampler, it applies to spans that were already recorded, e.g. to reduce
+// the number of spans exported by one of multiple SpanProcessors.
+//
+// The decision is based on the trace ID of a span so all spans of a trace
+// are either kept or dropped together. It uses the lower half of the trace
+// ID, not the upper half the TraceIDRatioBased Sampler uses, so combined
+// with such a Sampler the fraction of the sampled traces that is kept is
+// still fraction. An error is returned if fraction is not within [0, 1].
+func NewProbabilisticExportFilter(fraction float64, next SpanProcessor) (SpanProcessor, error) {
+	if !(fraction >= 0 && fraction <= 1) {
+		return nil, fmt.Errorf("probabilistic export filter fraction must be within [0, 1]: %v", fraction)
+	}
+	upperBound := uint64(fraction * (1 << 63))
+	return filterProcessor{
+		next: next,
+		keep: func(s ReadOnlySpan) bool {
+			traceID := s.SpanContext().TraceID()
+			return binary.BigEndian.Uint64(traceID[8:16])>>1 < upperBound
+		},
+	}, nil
+}
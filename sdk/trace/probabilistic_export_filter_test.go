@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestProbabilisticExportFilterInvalidFraction(t *testing.T) {
+	for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+		_, err := sdktrace.NewProbabilisticExportFilter(fraction, sdktrace.NewSimpleSpanProcessor(&testExporter{}))
+		assert.Error(t, err, fraction)
+	}
+}
+
+func TestProbabilisticExportFilter(t *testing.T) {
+	for _, fraction := range []float64{0, 0.25, 0.5, 1} {
+		te := &testExporter{}
+		sp, err := sdktrace.NewProbabilisticExportFilter(fraction, sdktrace.NewSimpleSpanProcessor(te))
+		require.NoError(t, err)
+		tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp)).Tracer("ProbabilisticExportFilter")
+
+		const traces = 1000
+		for i := 0; i < traces; i++ {
+			ctx, parent := tr.Start(context.Background(), "parent")
+			_, child := tr.Start(ctx, "child")
+			child.End()
+			parent.End()
+		}
+
+		// Spans of a trace are kept together.
+		perTrace := make(map[trace.TraceID]int)
+		for _, s := range te.spans {
+			perTrace[s.SpanContext.TraceID()]++
+		}
+		for id, n := range perTrace {
+			assert.Equal(t, 2, n, "trace %s", id)
+		}
+		assert.InDelta(t, fraction*traces, len(perTrace), 0.1*traces, "fraction %v", fraction)
+	}
+}
+
+func TestProbabilisticExportFilterIndependentOfSampler(t *testing.T) {
+	te := &testExporter{}
+	sp, err := sdktrace.NewProbabilisticExportFilter(0.5, sdktrace.NewSimpleSpanProcessor(te))
+	require.NoError(t, err)
+	tr := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sp),
+		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(0.5)),
+	).Tracer("ProbabilisticExportFilter")
+
+	const traces = 2000
+	for i := 0; i < traces; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	// Half of the half of the traces that are sampled, not min(0.5, 0.5).
+	assert.InDelta(t, 0.25*traces, len(te.spans), 0.05*traces)
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	errNoTerminalStage    = errors.New("processor chain has no export stage")
+	errStageAfterTerminal = errors.New("processor chain has a stage after its export stage")
+)
+
+// ProcessorChain builds a single SpanProcessor out of a sequence of stages.
+// Every ended span is passed through the stages in the order they were
+// added until it is dropped or reaches the final export stage. All stages
+// forward the OnStart, Shutdown, and ForceFlush calls to the following
+// stages.
+//
+// A ProcessorChain is not safe for concurrent use. The SpanProcessor it
+// builds is.
+type ProcessorChain struct {
+	stages []func(next SpanProcessor) SpanProcessor
+	// terminal returns the final stage. It is only called by Build once the
+	// chain is valid so no SpanProcessor is left running if it is not.
+	terminal func() SpanProcessor
+	err      error
+}
+
+// NewProcessorChain returns an empty ProcessorChain.
+func NewProcessorChain() *ProcessorChain {
+	return &ProcessorChain{}
+}
+
+func (c *ProcessorChain) add(stage func(next SpanProcessor) SpanProcessor) *ProcessorChain {
+	if c.terminal != nil && c.err == nil {
+		c.err = errStageAfterTerminal
+	}
+	c.stages = append(c.stages, stage)
+	return c
+}
+
+// Filter adds a stage passing only the spans for which keep returns true to
+// the following stages. All other spans are dropped.
+func (c *ProcessorChain) Filter(keep func(ReadOnlySpan) bool) *ProcessorChain {
+	return c.add(func(next SpanProcessor) SpanProcessor {
+		return filterProcessor{next: next, keep: keep}
+	})
+}
+
+// Truncate adds a stage limiting the attributes, events, and links of the
+// spans it passes to the following stages to limits. Everything removed is
+// accounted for in the respective dropped counts. Limits that are not
+// positive are replaced with their default value, the same as the
+// WithSpanLimits option does.
+func (c *ProcessorChain) Truncate(limits SpanLimits) *ProcessorChain {
+	limits.ensureDefault()
+	return c.add(func(next SpanProcessor) SpanProcessor {
+		return truncateProcessor{next: next, limits: limits}
+	})
+}
+
+// Branch adds a stage passing the spans for which match returns true to sp
+// instead of the following stages. Sp receives the OnStart, Shutdown, and
+// ForceFlush calls of all spans in addition to the following stages.
+func (c *ProcessorChain) Branch(match func(ReadOnlySpan) bool, sp SpanProcessor) *ProcessorChain {
+	return c.add(func(next SpanProcessor) SpanProcessor {
+		return branchProcessor{next: next, branch: sp, match: match}
+	})
+}
+
+// Export adds the final stage of the chain, exporting spans with exporter
+// using a BatchSpanProcessor configured with the passed opts. The
+// BatchSpanProcessor is created by Build, every successful call of Build
+// creates a new one.
+func (c *ProcessorChain) Export(exporter SpanExporter, opts ...BatchSpanProcessorOption) *ProcessorChain {
+	return c.then(func() SpanProcessor {
+		return NewBatchSpanProcessor(exporter, opts...)
+	})
+}
+
+// Then adds sp as the final stage of the chain.
+func (c *ProcessorChain) Then(sp SpanProcessor) *ProcessorChain {
+	return c.then(func() SpanProcessor { return sp })
+}
+
+func (c *ProcessorChain) then(terminal func() SpanProcessor) *ProcessorChain {
+	if c.terminal != nil && c.err == nil {
+		c.err = errStageAfterTerminal
+	}
+	c.terminal = terminal
+	return c
+}
+
+// Build returns a SpanProcessor running all the stages of the chain. An
+// error is returned if the chain has no final stage, added with Export or
+// Then, or if any stage was added after it.
+func (c *ProcessorChain) Build() (SpanProcessor, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.terminal == nil {
+		return nil, errNoTerminalStage
+	}
+	sp := c.terminal()
+	for i := len(c.stages) - 1; i >= 0; i-- {
+		sp = c.stages[i](sp)
+	}
+	return sp, nil
+}
+
+// filterProcessor drops the spans not kept by a predicate.
+type filterProcessor struct {
+	next SpanProcessor
+	keep func(ReadOnlySpan) bool
+}
+
+func (p filterProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p filterProcessor) OnEnd(s ReadOnlySpan) {
+	if p.keep(s) {
+		p.next.OnEnd(s)
+	}
+}
+
+func (p filterProcessor) Shutdown(ctx context.Context) error   { return p.next.Shutdown(ctx) }
+func (p filterProcessor) ForceFlush(ctx context.Context) error { return p.next.ForceFlush(ctx) }
+func (p filterProcessor) exportCounts() exportCounts           { return exportCountsOf(p.next) }
+
+// truncateProcessor limits the span data passed to the next SpanProcessor.
+type truncateProcessor struct {
+	next   SpanProcessor
+	limits SpanLimits
+}
+
+func (p truncateProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p truncateProcessor) OnEnd(s ReadOnlySpan) {
+	l := p.limits
+	if len(s.Attributes()) <= l.AttributeCountLimit &&
+		len(s.Events()) <= l.EventCountLimit &&
+		len(s.Links()) <= l.LinkCountLimit &&
+		!p.eventAttributesExceeded(s.Events()) &&
+		!p.linkAttributesExceeded(s.Links()) {
+		p.next.OnEnd(s)
+		return
+	}
+
+	ss := s.Snapshot()
+	// Like spans themselves, keep the most recent attributes, events, and
+	// links, and the first attributes of events and links.
+	if n := len(ss.Attributes) - l.AttributeCountLimit; n > 0 {
+		ss.Attributes = ss.Attributes[n:]
+		ss.DroppedAttributeCount += n
+	}
+	if n := len(ss.MessageEvents) - l.EventCountLimit; n > 0 {
+		ss.MessageEvents = ss.MessageEvents[n:]
+		ss.DroppedMessageEventCount += n
+	}
+	if p.eventAttributesExceeded(ss.MessageEvents) {
+		events := make([]Event, len(ss.MessageEvents))
+		for i, e := range ss.MessageEvents {
+			if n := len(e.Attributes) - l.AttributePerEventCountLimit; n > 0 {
+				e.Attributes = e.Attributes[:l.AttributePerEventCountLimit]
+				e.DroppedAttributeCount += n
+			}
+			events[i] = e
+		}
+		ss.MessageEvents = events
+	}
+	if n := len(ss.Links) - l.LinkCountLimit; n > 0 {
+		ss.Links = ss.Links[n:]
+		ss.DroppedLinkCount += n
+	}
+	if p.linkAttributesExceeded(ss.Links) {
+		links := make([]trace.Link, len(ss.Links))
+		for i, link := range ss.Links {
+			if n := len(link.Attributes) - l.AttributePerLinkCountLimit; n > 0 {
+				link.Attributes = link.Attributes[:l.AttributePerLinkCountLimit]
+				link.DroppedAttributeCount += n
+			}
+			links[i] = link
+		}
+		ss.Links = links
+	}
+	p.next.OnEnd(newSnapshotSpan(s, ss))
+}
+
+func (p truncateProcessor) eventAttributesExceeded(events []Event) bool {
+	for _, e := range events {
+		if len(e.Attributes) > p.limits.AttributePerEventCountLimit {
+			return true
+		}
+	}
+	return false
+}
+
+func (p truncateProcessor) linkAttributesExceeded(links []trace.Link) bool {
+	for _, link := range links {
+		if len(link.Attributes) > p.limits.AttributePerLinkCountLimit {
+			return true
+		}
+	}
+	return false
+}
+
+func (p truncateProcessor) Shutdown(ctx context.Context) error   { return p.next.Shutdown(ctx) }
+func (p truncateProcessor) ForceFlush(ctx context.Context) error { return p.next.ForceFlush(ctx) }
+func (p truncateProcessor) exportCounts() exportCounts           { return exportCountsOf(p.next) }
+
+// branchProcessor passes the spans matching a predicate to a separate
+// SpanProcessor.
+type branchProcessor struct {
+	next   SpanProcessor
+	branch SpanProcessor
+	match  func(ReadOnlySpan) bool
+}
+
+func (p branchProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	p.branch.OnStart(parent, s)
+	p.next.OnStart(parent, s)
+}
+
+func (p branchProcessor) OnEnd(s ReadOnlySpan) {
+	if p.match(s) {
+		p.branch.OnEnd(s)
+		return
+	}
+	p.next.OnEnd(s)
+}
+
+func (p branchProcessor) Shutdown(ctx context.Context) error {
+	err := p.branch.Shutdown(ctx)
+	if nextErr := p.next.Shutdown(ctx); err == nil {
+		err = nextErr
+	} else if nextErr != nil {
+		otel.Handle(nextErr)
+	}
+	return err
+}
+
+func (p branchProcessor) ForceFlush(ctx context.Context) error {
+	err := p.branch.ForceFlush(ctx)
+	if nextErr := p.next.ForceFlush(ctx); err == nil {
+		err = nextErr
+	} else if nextErr != nil {
+		otel.Handle(nextErr)
+	}
+	return err
+}
+
+func (p branchProcessor) exportCounts() exportCounts {
+	c := exportCountsOf(p.branch)
+	c.add(exportCountsOf(p.next))
+	return c
+}
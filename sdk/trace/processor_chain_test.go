@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestProcessorChainRequiresExportStage(t *testing.T) {
+	keep := func(sdktrace.ReadOnlySpan) bool { return true }
+
+	_, err := sdktrace.NewProcessorChain().Filter(keep).Build()
+	assert.Error(t, err)
+
+	_, err = sdktrace.NewProcessorChain().
+		Then(sdktrace.NewSimpleSpanProcessor(&testExporter{})).
+		Filter(keep).
+		Build()
+	assert.Error(t, err)
+
+	sp, err := sdktrace.NewProcessorChain().Export(&testExporter{}).Build()
+	require.NoError(t, err)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestProcessorChainFailedBuildStartsNoProcessor(t *testing.T) {
+	keep := func(sdktrace.ReadOnlySpan) bool { return true }
+	goroutines := runtime.NumGoroutine()
+
+	_, err := sdktrace.NewProcessorChain().Export(&testExporter{}).Filter(keep).Build()
+	assert.Error(t, err)
+	_, err = sdktrace.NewProcessorChain().Export(&testExporter{}).Export(&testExporter{}).Build()
+	assert.Error(t, err)
+	// A started BatchSpanProcessor runs until it is shut down.
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines, "processor left running by a failed Build")
+}
+
+func TestProcessorChain(t *testing.T) {
+	te, internal := &testExporter{}, &testExporter{}
+	sp, err := sdktrace.NewProcessorChain().
+		Filter(func(s sdktrace.ReadOnlySpan) bool {
+			return !strings.HasPrefix(s.Name(), "health")
+		}).
+		Branch(func(s sdktrace.ReadOnlySpan) bool {
+			return s.SpanKind() == trace.SpanKindInternal
+		}, sdktrace.NewSimpleSpanProcessor(internal)).
+		Truncate(sdktrace.SpanLimits{AttributeCountLimit: 1, EventCountLimit: 1}).
+		Then(sdktrace.NewSimpleSpanProcessor(te)).
+		Build()
+	require.NoError(t, err)
+
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp)).Tracer("ProcessorChain")
+	ctx := context.Background()
+	_, span := tr.Start(ctx, "healthcheck", trace.WithSpanKind(trace.SpanKindServer))
+	span.End()
+	_, span = tr.Start(ctx, "internal")
+	span.End()
+	_, span = tr.Start(ctx, "server", trace.WithSpanKind(trace.SpanKindServer))
+	span.SetAttributes(attribute.Int("a", 1), attribute.Int("b", 2))
+	span.AddEvent("first")
+	span.AddEvent("second")
+	span.End()
+
+	require.Len(t, internal.spans, 1)
+	assert.Equal(t, "internal", internal.spans[0].Name)
+
+	require.Len(t, te.spans, 1)
+	got := te.spans[0]
+	assert.Equal(t, "server", got.Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("b", 2)}, got.Attributes)
+	assert.Equal(t, 1, got.DroppedAttributeCount)
+	require.Len(t, got.MessageEvents, 1)
+	assert.Equal(t, "second", got.MessageEvents[0].Name)
+	assert.Equal(t, 1, got.DroppedMessageEventCount)
+
+	require.NoError(t, sp.Shutdown(ctx))
+	assert.True(t, te.shutdown)
+	assert.True(t, internal.shutdown)
+}
+
+func TestProcessorChainTruncatesEventAndLinkAttributes(t *testing.T) {
+	te := &testExporter{}
+	sp, err := sdktrace.NewProcessorChain().
+		Truncate(sdktrace.SpanLimits{AttributePerEventCountLimit: 1, AttributePerLinkCountLimit: 1}).
+		Then(sdktrace.NewSimpleSpanProcessor(te)).
+		Build()
+	require.NoError(t, err)
+
+	a, b := attribute.Int("a", 1), attribute.Int("b", 2)
+	link := trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x01},
+		}),
+		Attributes: []attribute.KeyValue{a, b},
+	}
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp)).Tracer("ProcessorChain")
+	_, span := tr.Start(context.Background(), "span", trace.WithLinks(link))
+	span.AddEvent("event", trace.WithAttributes(a, b))
+	span.End()
+
+	require.Len(t, te.spans, 1)
+	got := te.spans[0]
+	require.Len(t, got.MessageEvents, 1)
+	assert.Equal(t, []attribute.KeyValue{a}, got.MessageEvents[0].Attributes)
+	assert.Equal(t, 1, got.MessageEvents[0].DroppedAttributeCount)
+	require.Len(t, got.Links, 1)
+	assert.Equal(t, []attribute.KeyValue{a}, got.Links[0].Attributes)
+	assert.Equal(t, 1, got.Links[0].DroppedAttributeCount)
+}
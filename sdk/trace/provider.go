@@ -17,10 +17,13 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 import (
 	"context"
 	"fmt"
+	"os"
+	"reflect"
 	"sync"
 	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -48,19 +51,70 @@ type TracerProviderConfig struct {
 	spanLimits SpanLimits
 
 	// resource contains attributes representing an entity that produces telemetry.
+	// It is merged with the resource detected from the environment.
 	resource *resource.Resource
+
+	// warnResourceConflicts reports the attributes of resource overriding a
+	// different detected value.
+	warnResourceConflicts bool
+
+	// recordSamplerInfo adds attributes describing the sampler to spans.
+	recordSamplerInfo bool
+
+	// recordBaggage adds the baggage of the context spans are started with
+	// to the spans.
+	recordBaggage bool
+
+	// startObservers and endObservers are called for every recording span
+	// when it is started and ended.
+	startObservers []SpanObserver
+	endObservers   []SpanObserver
+
+	// maxSpanDepth is the maximum depth of the recorded spans, unlimited if
+	// not positive.
+	maxSpanDepth int
+
+	// defaultSpanAttributes are added to every recording span when started.
+	defaultSpanAttributes []attribute.KeyValue
+
+	// spanNameRedactor, if not nil, replaces the name of every recording
+	// span when ended.
+	spanNameRedactor func(string) string
+
+	// shutdownSignals are the signals the TracerProvider is shut down on,
+	// if shutdownOnSignal is true.
+	shutdownOnSignal bool
+	shutdownSignals  []os.Signal
 }
 
 type TracerProviderOption func(*TracerProviderConfig)
 
 type TracerProvider struct {
 	mu             sync.Mutex
-	namedTracer    map[instrumentation.Library]*tracer
+	namedTracer    map[tracerKey]*tracer
 	spanProcessors atomic.Value
 	sampler        Sampler
 	idGenerator    IDGenerator
 	spanLimits     SpanLimits
 	resource       *resource.Resource
+
+	recordSamplerInfo bool
+	recordBaggage     bool
+	maxSpanDepth      int
+
+	defaultSpanAttributes []attribute.KeyValue
+	spanNameRedactor      func(string) string
+
+	startObservers []SpanObserver
+	endObservers   []SpanObserver
+
+	// spanCounts are allocated separately to ensure the 64-bit alignment
+	// required by their atomic operations.
+	spanCounts *spanCounts
+
+	// signalDone is closed to stop handling the shutdown signals, if any.
+	signalDone     chan struct{}
+	signalStopOnce sync.Once
 }
 
 var _ trace.TracerProvider = &TracerProvider{}
@@ -85,17 +139,31 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 	ensureValidTracerProviderConfig(o)
 
 	tp := &TracerProvider{
-		namedTracer: make(map[instrumentation.Library]*tracer),
+		namedTracer: make(map[tracerKey]*tracer),
 		sampler:     o.sampler,
 		idGenerator: o.idGenerator,
 		spanLimits:  o.spanLimits,
 		resource:    o.resource,
+
+		recordSamplerInfo: o.recordSamplerInfo,
+		recordBaggage:     o.recordBaggage,
+		maxSpanDepth:      o.maxSpanDepth,
+		startObservers:    o.startObservers,
+		endObservers:      o.endObservers,
+		spanCounts:        &spanCounts{},
+
+		defaultSpanAttributes: o.defaultSpanAttributes,
+		spanNameRedactor:      o.spanNameRedactor,
 	}
 
 	for _, sp := range o.processors {
 		tp.RegisterSpanProcessor(sp)
 	}
 
+	if o.shutdownOnSignal {
+		tp.handleShutdownSignals(o.shutdownSignals)
+	}
+
 	return tp
 }
 
@@ -103,32 +171,99 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 // the given name and options does not exist it is created, otherwise the
 // existing Tracer is returned.
 //
-// If name is empty, DefaultTracerName is used instead.
+// If name is empty, DefaultTracerName is used instead. The Sampler passed
+// with WithTracerSampler, if any, is used instead of the TracerProvider
+// Sampler for the Spans created by the returned Tracer.
 //
 // This method is safe to be called concurrently.
 func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
-	c := trace.NewTracerConfig(opts...)
+	key := tracerKey{
+		instrumentationLibrary: p.instrumentationLibrary(name, opts),
+		sampler:                tracerSampler(opts),
+	}
+	if key.sampler != nil && !reflect.TypeOf(key.sampler).Comparable() {
+		// The Tracer cannot be looked up by this Sampler.
+		return &tracer{
+			provider:               p,
+			instrumentationLibrary: key.instrumentationLibrary,
+			sampler:                key.sampler,
+		}
+	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if name == "" {
-		name = defaultTracerName
-	}
-	il := instrumentation.Library{
-		Name:    name,
-		Version: c.InstrumentationVersion,
-	}
-	t, ok := p.namedTracer[il]
+	t, ok := p.namedTracer[key]
 	if !ok {
 		t = &tracer{
 			provider:               p,
-			instrumentationLibrary: il,
+			instrumentationLibrary: key.instrumentationLibrary,
+			sampler:                key.sampler,
 		}
-		p.namedTracer[il] = t
+		p.namedTracer[key] = t
 	}
 	return t
 }
 
+// tracerKey identifies the Tracers of a TracerProvider.
+type tracerKey struct {
+	instrumentationLibrary instrumentation.Library
+	sampler                Sampler
+}
+
+// tracerSamplerOption is the trace.TracerOption returned by
+// WithTracerSampler.
+type tracerSamplerOption struct {
+	// TracerOption is only embedded to implement the unexported method of
+	// the interface, it is always nil.
+	trace.TracerOption
+
+	sampler Sampler
+}
+
+// ApplyTracer does nothing, the Sampler is read by the TracerProvider.
+func (tracerSamplerOption) ApplyTracer(*trace.TracerConfig) {}
+
+// WithTracerSampler returns a TracerOption making the Tracer returned by the
+// TracerProvider use s instead of the TracerProvider Sampler to make the
+// sampling decisions for the Spans it creates. This allows sampling the
+// Spans of some instrumentation differently than all other Spans. If s is
+// nil, the TracerProvider Sampler is used.
+//
+// Tracers are cached per name, version and Sampler, so Tracers for the same
+// name without this option are not affected by s. A new Tracer is returned
+// for every call with a Sampler that is not comparable.
+//
+// The option is defined here as the trace package cannot refer to the SDK
+// Sampler. Other implementations of trace.TracerProvider ignore it.
+func WithTracerSampler(s Sampler) trace.TracerOption {
+	return tracerSamplerOption{sampler: s}
+}
+
+// tracerSampler returns the Sampler of the last WithTracerSampler option in
+// opts, or nil if there is none.
+func tracerSampler(opts []trace.TracerOption) Sampler {
+	var s Sampler
+	for _, opt := range opts {
+		if o, ok := opt.(tracerSamplerOption); ok {
+			s = o.sampler
+		}
+	}
+	return s
+}
+
+// instrumentationLibrary returns the instrumentation.Library of a Tracer
+// created with name and opts.
+func (p *TracerProvider) instrumentationLibrary(name string, opts []trace.TracerOption) instrumentation.Library {
+	c := trace.NewTracerConfig(opts...)
+	if name == "" {
+		name = defaultTracerName
+	}
+	return instrumentation.Library{
+		Name:    name,
+		Version: c.InstrumentationVersion,
+	}
+}
+
 // RegisterSpanProcessor adds the given SpanProcessor to the list of SpanProcessors
 func (p *TracerProvider) RegisterSpanProcessor(s SpanProcessor) {
 	p.mu.Lock()
@@ -207,7 +342,12 @@ func (p *TracerProvider) ForceFlush(ctx context.Context) error {
 }
 
 // Shutdown shuts down the span processors in the order they were registered.
+// It stops handling the signals set with WithShutdownOnSignal, if any.
+// Calling Shutdown more than once does not shut down the span processors
+// again.
 func (p *TracerProvider) Shutdown(ctx context.Context) error {
+	p.stopShutdownSignals()
+
 	spss, ok := p.spanProcessors.Load().(spanProcessorStates)
 	if !ok {
 		return fmt.Errorf("failed to load span processors")
@@ -264,11 +404,33 @@ func WithSpanProcessor(sp SpanProcessor) TracerProviderOption {
 // referenced by all the Tracers the TracerProvider creates. It represents the
 // entity producing telemetry.
 //
+// The Resource r is merged with the resource.Environment() Resource, the
+// attributes of r take precedence over the ones detected from the
+// environment. If this option is used multiple times, only the last Resource
+// is used. Use WithResourceConflictWarning to report the detected attributes
+// that are overridden.
+//
 // If this option is not used, the TracerProvider will use the
 // resource.Default() Resource by default.
 func WithResource(r *resource.Resource) TracerProviderOption {
 	return func(opts *TracerProviderConfig) {
-		opts.resource = resource.Merge(resource.Environment(), r)
+		if r == nil {
+			r = resource.Empty()
+		}
+		opts.resource = r
+	}
+}
+
+// WithResourceConflictWarning returns a TracerProviderOption that will
+// configure a TracerProvider to report every attribute of the Resource set
+// with WithResource that overrides an attribute detected from the
+// environment with a different value to the global error handler. The
+// attributes of the Resource set with WithResource always take precedence,
+// this only helps finding conflicting configurations, e.g. of the
+// service.name.
+func WithResourceConflictWarning() TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.warnResourceConflicts = true
 	}
 }
 
@@ -315,6 +477,125 @@ func WithSpanLimits(sl SpanLimits) TracerProviderOption {
 	}
 }
 
+// WithRecordSamplerInfo returns a TracerProviderOption that will configure a
+// TracerProvider to record the Sampler that made the sampling decision of
+// every recording Span it creates as attributes of the Span. The name of the
+// Sampler is recorded with the SamplerNameKey and, if the Sampler has any,
+// its parameters with the SamplerParamKey. Both are derived from the
+// Description of the Sampler.
+//
+// This is intended to help diagnosing the sampling configuration and is
+// disabled by default.
+func WithRecordSamplerInfo() TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.recordSamplerInfo = true
+	}
+}
+
+// WithRecordBaggage returns a TracerProviderOption that will configure a
+// TracerProvider to record all baggage entries of the context a recording
+// Span is started with as attributes of the Span. The keys of the attributes
+// are the baggage keys prefixed with BaggageKeyPrefix. This shows the
+// context that was propagated when the Span ran.
+//
+// Baggage can hold sensitive information which is exported with the Spans
+// when this option is used. It is disabled by default.
+func WithRecordBaggage() TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.recordBaggage = true
+	}
+}
+
+// WithSpanStartObserver returns a TracerProviderOption that will configure a
+// TracerProvider to call o for every recording Span its Tracers start, after
+// the Span is passed to the registered SpanProcessors. This option can be
+// used multiple times to register multiple SpanObservers.
+//
+// A panic of o is recovered and reported to the global error handler.
+func WithSpanStartObserver(o SpanObserver) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		if o != nil {
+			opts.startObservers = append(opts.startObservers, o)
+		}
+	}
+}
+
+// WithSpanEndObserver returns a TracerProviderOption that will configure a
+// TracerProvider to call o for every recording Span its Tracers start when
+// the Span ends, after the Span is passed to the registered SpanProcessors.
+// This option can be used multiple times to register multiple SpanObservers.
+//
+// A panic of o is recovered and reported to the global error handler.
+func WithSpanEndObserver(o SpanObserver) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		if o != nil {
+			opts.endObservers = append(opts.endObservers, o)
+		}
+	}
+}
+
+// WithMaxSpanDepth returns a TracerProviderOption that will configure a
+// TracerProvider to limit the depth of the span trees its Tracers record to
+// max. The depth of a Span is stored in the context returned by Start: a
+// root Span, a Span with a remote parent, or a new root Span, has a depth of
+// 1, and the children of a Span have its depth plus 1. Spans deeper than max
+// are dropped, they are not recording nor sampled, as are their descendants.
+// This protects against accidental recursion creating runaway span trees.
+//
+// If this option is not used, or max is not positive, the depth is not
+// limited.
+func WithMaxSpanDepth(max int) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.maxSpanDepth = max
+	}
+}
+
+// WithDefaultSpanAttributes returns a TracerProviderOption that will
+// configure a TracerProvider to add attrs to every recording Span its Tracers
+// start. Unlike the attributes of the Resource, these are attributes of each
+// Span: they are set before any other attribute of the Span, so the
+// attributes of the Sampler, of the StartOptions, or set by the
+// instrumentation afterwards, with the same key override them.
+//
+// If this option is used multiple times, attrs are appended.
+func WithDefaultSpanAttributes(attrs ...attribute.KeyValue) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.defaultSpanAttributes = append(opts.defaultSpanAttributes, attrs...)
+	}
+}
+
+// WithSpanNameRedactor returns a TracerProviderOption that will configure a
+// TracerProvider to replace the name of every recording Span its Tracers
+// start by the result of redact when the Span is ended, before it is passed
+// to the SpanProcessors. This sanitizes names leaking sensitive data, e.g.
+// identifiers or tokens in paths, before they are exported. See
+// RedactSpanNameIDs for a redactor of common identifier patterns.
+//
+// The redact function is called with the Span locked, it must not call
+// methods of the Span.
+func WithSpanNameRedactor(redact func(name string) string) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.spanNameRedactor = redact
+	}
+}
+
+// WithShutdownOnSignal returns a TracerProviderOption that will configure a
+// TracerProvider to flush and shut down its span processors when the process
+// receives one of signals, os.Interrupt and syscall.SIGTERM if none is
+// passed. This avoids losing the last spans of a command or job
+// terminated by a signal. The flush and shutdown are given
+// defaultSignalShutdownTimeout to complete, then the signal is raised again
+// for the process to terminate as it would have without this option.
+//
+// The signals are not handled anymore once Shutdown is called, calling
+// Shutdown before or after a signal is received is safe.
+func WithShutdownOnSignal(signals ...os.Signal) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.shutdownOnSignal = true
+		opts.shutdownSignals = signals
+	}
+}
+
 // ensureValidTracerProviderConfig ensures that given TracerProviderConfig is valid.
 func ensureValidTracerProviderConfig(cfg *TracerProviderConfig) {
 	if cfg.sampler == nil {
@@ -323,8 +604,27 @@ func ensureValidTracerProviderConfig(cfg *TracerProviderConfig) {
 	if cfg.idGenerator == nil {
 		cfg.idGenerator = defaultIDGenerator()
 	}
+	cfg.spanLimits.fromEnv()
 	cfg.spanLimits.ensureDefault()
 	if cfg.resource == nil {
 		cfg.resource = resource.Default()
+	} else {
+		detected := resource.Environment()
+		if cfg.warnResourceConflicts {
+			reportResourceConflicts(detected, cfg.resource)
+		}
+		cfg.resource = resource.Merge(detected, cfg.resource)
+	}
+}
+
+// reportResourceConflicts reports the attributes of explicit overriding an
+// attribute of detected with a different value to the global error handler.
+func reportResourceConflicts(detected, explicit *resource.Resource) {
+	set := detected.Set()
+	for iter := explicit.Iter(); iter.Next(); {
+		kv := iter.Label()
+		if v, ok := set.Value(kv.Key); ok && v != kv.Value {
+			otel.Handle(fmt.Errorf("resource attribute %s=%s detected from the environment is overridden by %s=%s", kv.Key, v.Emit(), kv.Key, kv.Value.Emit()))
+		}
 	}
 }
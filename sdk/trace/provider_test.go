@@ -82,3 +82,50 @@ func TestFailedProcessorShutdownInUnregister(t *testing.T) {
 	err := stp.Shutdown(context.Background())
 	assert.NoError(t, err)
 }
+
+func TestTracerWithSampler(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSampler(NeverSample()), WithSyncer(te))
+
+	critical := tp.Tracer("critical", WithTracerSampler(AlwaysSample()))
+	_, span := critical.Start(context.Background(), "sampled")
+	span.End()
+
+	// The override does not apply to other Tracers, including those with
+	// the same name.
+	_, span = tp.Tracer("critical").Start(context.Background(), "not sampled")
+	span.End()
+	_, span = tp.Tracer("other").Start(context.Background(), "not sampled")
+	span.End()
+
+	_, span = tp.Tracer("default", WithTracerSampler(nil)).Start(context.Background(), "not sampled")
+	span.End()
+
+	spans := te.Spans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "sampled", spans[0].Name)
+		assert.Equal(t, "critical", spans[0].InstrumentationLibrary.Name)
+	}
+	assert.NotSame(t, critical, tp.Tracer("critical"))
+	assert.Same(t, critical, tp.Tracer("critical", WithTracerSampler(AlwaysSample())))
+	assert.NotSame(t, critical, tp.Tracer("critical", WithTracerSampler(TraceIDRatioBased(0.5))))
+	assert.Same(t, tp.Tracer("default"), tp.Tracer("default", WithTracerSampler(nil)))
+}
+
+// funcSampler is a Sampler that is not comparable.
+type funcSampler func(SamplingParameters) SamplingResult
+
+func (f funcSampler) ShouldSample(p SamplingParameters) SamplingResult { return f(p) }
+func (funcSampler) Description() string                                { return "funcSampler" }
+
+func TestTracerWithNotComparableSampler(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSampler(NeverSample()), WithSyncer(te))
+	s := funcSampler(func(SamplingParameters) SamplingResult {
+		return SamplingResult{Decision: RecordAndSample}
+	})
+
+	_, span := tp.Tracer("critical", WithTracerSampler(s)).Start(context.Background(), "sampled")
+	span.End()
+	assert.Len(t, te.Spans(), 1)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceDedupSpanProcessor is a SpanProcessor that removes the attributes
+// of an ended span duplicating an attribute of its resource before passing
+// the span to the next SpanProcessor.
+type resourceDedupSpanProcessor struct {
+	next SpanProcessor
+}
+
+var _ SpanProcessor = resourceDedupSpanProcessor{}
+
+// NewResourceDedupSpanProcessor returns a SpanProcessor that removes the
+// attributes of an ended span that are equal to an attribute of the span
+// resource, with the same key and value, before passing the span to next.
+// Attributes with the key of a resource attribute but a different value are
+// kept. This avoids exporting the same information twice.
+//
+// The removed attributes are not counted as dropped attributes.
+func NewResourceDedupSpanProcessor(next SpanProcessor) SpanProcessor {
+	return resourceDedupSpanProcessor{next: next}
+}
+
+// OnStart passes s to the next SpanProcessor.
+func (p resourceDedupSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s without the attributes duplicating its resource to the next
+// SpanProcessor.
+func (p resourceDedupSpanProcessor) OnEnd(s ReadOnlySpan) {
+	res := s.Resource()
+	attrs := s.Attributes()
+	if res.Len() == 0 || !hasResourceAttribute(res, attrs) {
+		p.next.OnEnd(s)
+		return
+	}
+
+	ss := s.Snapshot()
+	kept := make([]attribute.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if !isResourceAttribute(res, kv) {
+			kept = append(kept, kv)
+		}
+	}
+	ss.Attributes = kept
+	p.next.OnEnd(newSnapshotSpan(s, ss))
+}
+
+// Shutdown shuts down the next SpanProcessor.
+func (p resourceDedupSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next SpanProcessor.
+func (p resourceDedupSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p resourceDedupSpanProcessor) exportCounts() exportCounts { return exportCountsOf(p.next) }
+
+// hasResourceAttribute returns if any of attrs is an attribute of res.
+func hasResourceAttribute(res *resource.Resource, attrs []attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if isResourceAttribute(res, kv) {
+			return true
+		}
+	}
+	return false
+}
+
+// isResourceAttribute returns if res has an attribute with the key and value
+// of kv.
+func isResourceAttribute(res *resource.Resource, kv attribute.KeyValue) bool {
+	v, ok := res.Set().Value(kv.Key)
+	return ok && v == kv.Value
+}
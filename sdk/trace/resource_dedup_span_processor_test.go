@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestResourceDedupSpanProcessor(t *testing.T) {
+	te := &testExporter{}
+	res := resource.NewWithAttributes(
+		attribute.String("service.name", "svc"),
+		attribute.String("env", "prod"),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(sdktrace.NewResourceDedupSpanProcessor(sdktrace.NewSimpleSpanProcessor(te))),
+	)
+	tr := tp.Tracer("ResourceDedupSpanProcessor")
+
+	_, span := tr.Start(context.Background(), "duplicates")
+	span.SetAttributes(
+		attribute.String("service.name", "svc"),
+		attribute.String("env", "dev"),
+		attribute.Int("other", 1),
+	)
+	span.End()
+
+	_, span = tr.Start(context.Background(), "unique")
+	span.SetAttributes(attribute.Int("other", 1))
+	span.End()
+
+	require.Len(t, te.spans, 2)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("env", "dev"),
+		attribute.Int("other", 1),
+	}, te.spans[0].Attributes)
+	assert.Equal(t, 0, te.spans[0].DroppedAttributeCount)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("other", 1)}, te.spans[1].Attributes)
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.True(t, te.shutdown)
+}
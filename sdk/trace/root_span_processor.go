@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+// NewRootSpanProcessor returns a SpanProcessor that passes only the ended
+// root spans, spans without a valid parent, to next. All other spans,
+// including spans with a remote parent, are dropped. This provides a coarse
+// view of the traced operations without their full span trees.
+func NewRootSpanProcessor(next SpanProcessor) SpanProcessor {
+	return filterProcessor{next: next, keep: isRoot}
+}
+
+// isRoot returns if s has no valid parent.
+func isRoot(s ReadOnlySpan) bool {
+	return !s.Parent().IsValid()
+}
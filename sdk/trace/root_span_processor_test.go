@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRootSpanProcessor(t *testing.T) {
+	te := &testExporter{}
+	rsp := sdktrace.NewRootSpanProcessor(sdktrace.NewSimpleSpanProcessor(te))
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rsp)).Tracer("RootSpan")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	remote := trace.ContextWithRemoteSpanContext(context.Background(), root.SpanContext())
+	_, span := tr.Start(remote, "remote child")
+	span.End()
+
+	require.Len(t, te.spans, 1)
+	assert.Equal(t, "root", te.spans[0].Name)
+
+	require.NoError(t, rsp.Shutdown(context.Background()))
+	assert.True(t, te.shutdown)
+}
@@ -18,11 +18,25 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	// SamplerNameKey is the attribute key used to record the name of the
+	// Sampler of a span when the WithRecordSamplerInfo option is used.
+	SamplerNameKey = attribute.Key("sampler.name")
+	// SamplerParamKey is the attribute key used to record the parameters
+	// of the Sampler of a span when the WithRecordSamplerInfo option is
+	// used.
+	SamplerParamKey = attribute.Key("sampler.param")
+)
+
 // Sampler decides whether a trace should be sampled and exported.
 type Sampler interface {
 	ShouldSample(parameters SamplingParameters) SamplingResult
@@ -61,11 +75,46 @@ type SamplingResult struct {
 	Decision   SamplingDecision
 	Attributes []attribute.KeyValue
 	Tracestate trace.TraceState
+
+	// TracestateEntries are merged into the tracestate of the new span,
+	// ahead of and replacing any existing entries with the same key. They
+	// are merged into Tracestate, or into the parent tracestate if
+	// Tracestate is empty. Invalid entries are reported to the global
+	// error handler and ignored.
+	TracestateEntries []attribute.KeyValue
+}
+
+// traceStateOf returns the tracestate for a new span from the sampling
+// result sr of a span with the parent span context psc.
+func traceStateOf(sr SamplingResult, psc trace.SpanContext) trace.TraceState {
+	if len(sr.TracestateEntries) == 0 {
+		return sr.Tracestate
+	}
+	ts := sr.Tracestate
+	if ts.IsEmpty() {
+		ts = psc.TraceState()
+	}
+	// Insert in reverse so the entries keep their order at the front.
+	for i := len(sr.TracestateEntries) - 1; i >= 0; i-- {
+		merged, err := ts.Insert(sr.TracestateEntries[i])
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		ts = merged
+	}
+	return ts
 }
 
+// SamplingProbabilityKey is the attribute key used to record the probability
+// a span was sampled with.
+const SamplingProbabilityKey = attribute.Key("sampling.probability")
+
 type traceIDRatioSampler struct {
 	traceIDUpperBound uint64
 	description       string
+	// attributes are the attributes of the sampled spans.
+	attributes []attribute.KeyValue
 }
 
 func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult {
@@ -74,6 +123,7 @@ func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult
 	if x < ts.traceIDUpperBound {
 		return SamplingResult{
 			Decision:   RecordAndSample,
+			Attributes: ts.attributes,
 			Tracestate: psc.TraceState(),
 		}
 	}
@@ -92,21 +142,66 @@ func (ts traceIDRatioSampler) Description() string {
 // parent trace's `SampledFlag`, the `TraceIDRatioBased` sampler should be used
 // as a delegate of a `Parent` sampler.
 //nolint:golint // golint complains about stutter of `trace.TraceIDRatioBased`
-func TraceIDRatioBased(fraction float64) Sampler {
+func TraceIDRatioBased(fraction float64, options ...TraceIDRatioBasedOption) Sampler {
+	var c traceIDRatioConfig
+	for _, o := range options {
+		o.Apply(&c)
+	}
+
 	if fraction >= 1 {
-		return AlwaysSample()
+		if !c.probabilityAttribute {
+			return AlwaysSample()
+		}
+		fraction = 1
 	}
 
 	if fraction <= 0 {
 		fraction = 0
 	}
 
-	return &traceIDRatioSampler{
+	ts := &traceIDRatioSampler{
 		traceIDUpperBound: uint64(fraction * (1 << 63)),
 		description:       fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
 	}
+	if c.probabilityAttribute {
+		ts.attributes = []attribute.KeyValue{SamplingProbabilityKey.Float64(fraction)}
+	}
+	return ts
+}
+
+// traceIDRatioConfig is a group of options for traceIDRatioSampler.
+type traceIDRatioConfig struct {
+	probabilityAttribute bool
+}
+
+// TraceIDRatioBasedOption configures the TraceIDRatioBased sampler.
+type TraceIDRatioBasedOption interface {
+	Apply(*traceIDRatioConfig)
+
+	// A private method to prevent users implementing the
+	// interface and so future additions to it will not
+	// violate compatibility.
+	private()
 }
 
+// WithSamplingProbability sets the sampler to record the fraction of traces
+// it samples as a SamplingProbabilityKey attribute of the spans it samples,
+// so the counts of spans can be upscaled by analytics. The attribute is set
+// when the spans start, it is available to the SpanProcessors from OnStart.
+// When the sampler is the root sampler of a ParentBased sampler, it is only
+// recorded on root spans.
+func WithSamplingProbability() TraceIDRatioBasedOption {
+	return samplingProbabilityOption(true)
+}
+
+type samplingProbabilityOption bool
+
+func (o samplingProbabilityOption) Apply(config *traceIDRatioConfig) {
+	config.probabilityAttribute = bool(o)
+}
+
+func (samplingProbabilityOption) private() {}
+
 type alwaysOnSampler struct{}
 
 func (as alwaysOnSampler) ShouldSample(p SamplingParameters) SamplingResult {
@@ -288,3 +383,51 @@ func (pb parentBased) Description() string {
 		pb.config.localParentNotSampled.Description(),
 	)
 }
+
+// BaggageForceSampler returns a Sampler that samples every span started
+// with key in the baggage of its parent context, and delegates the sampling
+// decision of all other spans to fallback. This allows forcing the sampling
+// of selected requests, e.g. for debugging, by setting the baggage key in an
+// upstream service. The key is ignored if its value is false, as parsed by
+// strconv.ParseBool.
+func BaggageForceSampler(key string, fallback Sampler) Sampler {
+	return baggageForceSampler{key: attribute.Key(key), fallback: fallback}
+}
+
+type baggageForceSampler struct {
+	key      attribute.Key
+	fallback Sampler
+}
+
+func (bs baggageForceSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	v := baggage.Value(p.ParentContext, bs.key)
+	if v.Type() == attribute.INVALID {
+		return bs.fallback.ShouldSample(p)
+	}
+	if force, err := strconv.ParseBool(v.Emit()); err == nil && !force {
+		return bs.fallback.ShouldSample(p)
+	}
+	return SamplingResult{
+		Decision:   RecordAndSample,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (bs baggageForceSampler) Description() string {
+	return fmt.Sprintf("BaggageForceSampler{key:%s,fallback:%s}", bs.key, bs.fallback.Description())
+}
+
+// samplerInfo returns attributes describing s. The Description of s is
+// expected to use the "name{param}" form of the samplers in this package.
+func samplerInfo(s Sampler) []attribute.KeyValue {
+	desc := s.Description()
+	i := strings.IndexByte(desc, '{')
+	if i < 0 || !strings.HasSuffix(desc, "}") {
+		return []attribute.KeyValue{SamplerNameKey.String(desc)}
+	}
+	info := []attribute.KeyValue{SamplerNameKey.String(desc[:i])}
+	if param := desc[i+1 : len(desc)-1]; param != "" {
+		info = append(info, SamplerParamKey.String(param))
+	}
+	return info
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -258,3 +259,130 @@ func TestTracestateIsPassed(t *testing.T) {
 		})
 	}
 }
+
+func TestSamplerInfo(t *testing.T) {
+	tests := []struct {
+		sampler Sampler
+		want    []attribute.KeyValue
+	}{
+		{
+			sampler: AlwaysSample(),
+			want:    []attribute.KeyValue{SamplerNameKey.String("AlwaysOnSampler")},
+		},
+		{
+			sampler: TraceIDRatioBased(0.5),
+			want: []attribute.KeyValue{
+				SamplerNameKey.String("TraceIDRatioBased"),
+				SamplerParamKey.String("0.5"),
+			},
+		},
+		{
+			sampler: ParentBased(NeverSample()),
+			want: []attribute.KeyValue{
+				SamplerNameKey.String("ParentBased"),
+				SamplerParamKey.String("root:AlwaysOffSampler,remoteParentSampled:AlwaysOnSampler," +
+					"remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler," +
+					"localParentNotSampled:AlwaysOffSampler"),
+			},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, samplerInfo(test.sampler), test.sampler.Description())
+	}
+}
+
+func TestBaggageForceSampler(t *testing.T) {
+	const key = "otel.force_sample"
+	sampler := BaggageForceSampler(key, NeverSample())
+	assert.Equal(t, "BaggageForceSampler{key:otel.force_sample,fallback:AlwaysOffSampler}", sampler.Description())
+
+	traceState, err := trace.TraceStateFromKeyValues(attribute.String("k", "v"))
+	require.NoError(t, err)
+	parent := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceState: traceState,
+	}))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want SamplingDecision
+	}{
+		{name: "no baggage", ctx: parent, want: Drop},
+		{name: "other key", ctx: baggage.ContextWithValues(parent, attribute.String("other", "1")), want: Drop},
+		{name: "forced", ctx: baggage.ContextWithValues(parent, attribute.String(key, "1")), want: RecordAndSample},
+		{name: "forced without value", ctx: baggage.ContextWithValues(parent, attribute.String(key, "")), want: RecordAndSample},
+		{name: "disabled", ctx: baggage.ContextWithValues(parent, attribute.String(key, "false")), want: Drop},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := sampler.ShouldSample(SamplingParameters{ParentContext: test.ctx})
+			assert.Equal(t, test.want, got.Decision)
+			assert.Equal(t, traceState, got.Tracestate)
+		})
+	}
+}
+
+func TestTraceIDRatioBasedSamplingProbability(t *testing.T) {
+	sampler := TraceIDRatioBased(0.25, WithSamplingProbability())
+	assert.Equal(t, "TraceIDRatioBased{0.25}", sampler.Description())
+
+	sampled := sampler.ShouldSample(SamplingParameters{TraceID: trace.TraceID{0x01}})
+	assert.Equal(t, RecordAndSample, sampled.Decision)
+	assert.Equal(t, []attribute.KeyValue{SamplingProbabilityKey.Float64(0.25)}, sampled.Attributes)
+
+	dropped := sampler.ShouldSample(SamplingParameters{TraceID: trace.TraceID{0xff}})
+	assert.Equal(t, Drop, dropped.Decision)
+	assert.Empty(t, dropped.Attributes)
+
+	for _, fraction := range []float64{1, 2} {
+		sampler := TraceIDRatioBased(fraction, WithSamplingProbability())
+		result := sampler.ShouldSample(SamplingParameters{TraceID: trace.TraceID{0xff}})
+		assert.Equal(t, RecordAndSample, result.Decision)
+		assert.Equal(t, []attribute.KeyValue{SamplingProbabilityKey.Float64(1)}, result.Attributes)
+	}
+
+	// Without the option, no attribute is recorded.
+	result := TraceIDRatioBased(0.25).ShouldSample(SamplingParameters{TraceID: trace.TraceID{0x01}})
+	assert.Empty(t, result.Attributes)
+}
+
+// startAttributesProcessor records the attributes of the spans when they
+// start.
+type startAttributesProcessor struct {
+	attributes [][]attribute.KeyValue
+}
+
+func (p *startAttributesProcessor) OnStart(_ context.Context, s ReadWriteSpan) {
+	p.attributes = append(p.attributes, s.Attributes())
+}
+
+func (p *startAttributesProcessor) OnEnd(ReadOnlySpan)               {}
+func (p *startAttributesProcessor) Shutdown(context.Context) error   { return nil }
+func (p *startAttributesProcessor) ForceFlush(context.Context) error { return nil }
+
+func TestSamplingProbabilityOnRootSpan(t *testing.T) {
+	te := NewTestExporter()
+	sp := &startAttributesProcessor{}
+	tp := NewTracerProvider(
+		WithSampler(ParentBased(TraceIDRatioBased(1, WithSamplingProbability()))),
+		WithSyncer(te),
+		WithSpanProcessor(sp),
+	)
+	tr := tp.Tracer("SamplingProbability")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	want := []attribute.KeyValue{SamplingProbabilityKey.Float64(1)}
+	require.Len(t, sp.attributes, 2)
+	assert.Equal(t, want, sp.attributes[0])
+	assert.Empty(t, sp.attributes[1])
+
+	require.Len(t, te.spans, 2)
+	assert.Empty(t, te.spans[0].Attributes)
+	assert.Equal(t, want, te.spans[1].Attributes)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SelfTimeKey is the attribute key used to record the self time of a span in
+// milliseconds: its duration minus the durations of its direct children.
+const SelfTimeKey = attribute.Key("self_time_ms")
+
+// defaultMaxSelfTimeSpans is the default maximum number of started spans a
+// self time span processor tracks.
+const defaultMaxSelfTimeSpans = 4096
+
+// spanKey identifies a span across traces.
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+func spanKeyOf(sc trace.SpanContext) spanKey {
+	return spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+}
+
+// selfTimeSpanProcessor is a SpanProcessor that records the self time of
+// ended spans before passing them to the next SpanProcessor.
+type selfTimeSpanProcessor struct {
+	next     SpanProcessor
+	maxSpans int
+
+	mu sync.Mutex
+	// spans holds the elements of order by span.
+	spans map[spanKey]*list.Element
+	// order holds the *startedSpan of the tracked spans, the most recently
+	// used first.
+	order *list.List
+}
+
+// startedSpan is a span that has started but not ended yet.
+type startedSpan struct {
+	key spanKey
+	// children is the sum of the durations of the ended direct children of
+	// the span.
+	children time.Duration
+}
+
+var _ SpanProcessor = (*selfTimeSpanProcessor)(nil)
+
+// NewSelfTimeProcessor returns a SpanProcessor that records the self time
+// of every ended span as a SelfTimeKey attribute before passing the span to
+// next. The self time is the duration of the span minus the sum of the
+// durations of its direct children, a measure of the time spent in the span
+// itself.
+//
+// Only children started and ended while their parent is started are
+// accounted for: a child ending after its parent is not subtracted from the
+// parent self time, which is then over-estimated. The durations of children
+// running concurrently are summed even when they overlap, the self time is
+// not reported as less than 0 in that case. Remote children, spans of other
+// processes, cannot be accounted for.
+//
+// The processor tracks the started spans until they end, at most maxSpans
+// spans, or 4096 if maxSpans is not positive, are tracked at a time. When
+// the limit is reached, the least recently used span is forgotten: its
+// children ending after are not accounted for, its self time is its
+// duration. This bounds the memory used for spans that are never ended.
+func NewSelfTimeProcessor(next SpanProcessor, maxSpans int) SpanProcessor {
+	if maxSpans <= 0 {
+		maxSpans = defaultMaxSelfTimeSpans
+	}
+	return &selfTimeSpanProcessor{
+		next:     next,
+		maxSpans: maxSpans,
+		spans:    make(map[spanKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// OnStart tracks s so its children are accounted for and passes s to the
+// next SpanProcessor.
+func (p *selfTimeSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	key := spanKeyOf(s.SpanContext())
+	p.mu.Lock()
+	if _, ok := p.spans[key]; !ok {
+		if p.order.Len() >= p.maxSpans {
+			oldest := p.order.Back()
+			p.order.Remove(oldest)
+			delete(p.spans, oldest.Value.(*startedSpan).key)
+		}
+		p.spans[key] = p.order.PushFront(&startedSpan{key: key})
+	}
+	p.mu.Unlock()
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd passes s with its self time recorded to the next SpanProcessor.
+func (p *selfTimeSpanProcessor) OnEnd(s ReadOnlySpan) {
+	d := s.EndTime().Sub(s.StartTime())
+
+	var children time.Duration
+	p.mu.Lock()
+	key := spanKeyOf(s.SpanContext())
+	if e, ok := p.spans[key]; ok {
+		children = e.Value.(*startedSpan).children
+		p.order.Remove(e)
+		delete(p.spans, key)
+	}
+	if psc := s.Parent(); psc.IsValid() && !psc.IsRemote() {
+		// Only account for the child if the parent has not ended yet.
+		if e, ok := p.spans[spanKeyOf(psc)]; ok {
+			e.Value.(*startedSpan).children += d
+			p.order.MoveToFront(e)
+		}
+	}
+	p.mu.Unlock()
+
+	self := d - children
+	if self < 0 {
+		self = 0
+	}
+	ss := s.Snapshot()
+	attrs := make([]attribute.KeyValue, len(ss.Attributes), len(ss.Attributes)+1)
+	copy(attrs, ss.Attributes)
+	ss.Attributes = append(attrs, SelfTimeKey.Float64(float64(self)/float64(time.Millisecond)))
+	p.next.OnEnd(newSnapshotSpan(s, ss))
+}
+
+// Shutdown shuts down the next SpanProcessor.
+func (p *selfTimeSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next SpanProcessor.
+func (p *selfTimeSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *selfTimeSpanProcessor) exportCounts() exportCounts { return exportCountsOf(p.next) }
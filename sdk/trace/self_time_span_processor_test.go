@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func selfTime(t *testing.T, ss *sdktrace.SpanSnapshot) float64 {
+	for _, kv := range ss.Attributes {
+		if kv.Key == sdktrace.SelfTimeKey {
+			return kv.Value.AsFloat64()
+		}
+	}
+	t.Fatalf("span %q has no self time", ss.Name)
+	return 0
+}
+
+func TestSelfTimeProcessor(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewSelfTimeProcessor(sdktrace.NewSimpleSpanProcessor(te), 0)),
+	)
+	tr := tp.Tracer("SelfTimeProcessor")
+	start := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	ctx, parent := tr.Start(context.Background(), "parent", trace.WithTimestamp(at(0)))
+	parent.SetAttributes(attribute.Int("key", 1))
+	cctx, child := tr.Start(ctx, "child", trace.WithTimestamp(at(10)))
+	_, grandchild := tr.Start(cctx, "grandchild", trace.WithTimestamp(at(20)))
+	grandchild.End(trace.WithTimestamp(at(25)))
+	child.End(trace.WithTimestamp(at(40)))
+	_, late := tr.Start(ctx, "late", trace.WithTimestamp(at(90)))
+	parent.End(trace.WithTimestamp(at(100)))
+	late.End(trace.WithTimestamp(at(120)))
+
+	require.Len(t, te.spans, 4)
+	assert.Equal(t, "grandchild", te.spans[0].Name)
+	assert.Equal(t, 5.0, selfTime(t, te.spans[0]))
+	assert.Equal(t, "child", te.spans[1].Name)
+	assert.Equal(t, 25.0, selfTime(t, te.spans[1]))
+	assert.Equal(t, "parent", te.spans[2].Name)
+	// The late child ending after its parent is not accounted for.
+	assert.Equal(t, 70.0, selfTime(t, te.spans[2]))
+	assert.Equal(t, attribute.Int("key", 1), te.spans[2].Attributes[0])
+	assert.Equal(t, "late", te.spans[3].Name)
+	assert.Equal(t, 30.0, selfTime(t, te.spans[3]))
+}
+
+func TestSelfTimeProcessorOverlappingChildren(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewSelfTimeProcessor(sdktrace.NewSimpleSpanProcessor(te), 0)),
+	)
+	tr := tp.Tracer("SelfTimeProcessor")
+	start := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	ctx, parent := tr.Start(context.Background(), "parent", trace.WithTimestamp(start))
+	for i := 0; i < 3; i++ {
+		_, child := tr.Start(ctx, "child", trace.WithTimestamp(start))
+		child.End(trace.WithTimestamp(start.Add(10 * time.Millisecond)))
+	}
+	parent.End(trace.WithTimestamp(start.Add(20 * time.Millisecond)))
+
+	require.Len(t, te.spans, 4)
+	assert.Equal(t, 0.0, selfTime(t, te.spans[3]))
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.True(t, te.shutdown)
+}
+
+func TestSelfTimeProcessorMaxSpans(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewSelfTimeProcessor(sdktrace.NewSimpleSpanProcessor(te), 2)),
+	)
+	tr := tp.Tracer("SelfTimeProcessor")
+	start := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	// The never ended span is forgotten when the limit is reached.
+	_, _ = tr.Start(context.Background(), "never ended", trace.WithTimestamp(at(0)))
+	ctx, parent := tr.Start(context.Background(), "parent", trace.WithTimestamp(at(0)))
+	_, child := tr.Start(ctx, "child", trace.WithTimestamp(at(10)))
+	child.End(trace.WithTimestamp(at(20)))
+	parent.End(trace.WithTimestamp(at(100)))
+
+	ctx, evicted := tr.Start(context.Background(), "evicted", trace.WithTimestamp(at(0)))
+	_, other := tr.Start(context.Background(), "other", trace.WithTimestamp(at(0)))
+	_, child = tr.Start(ctx, "child", trace.WithTimestamp(at(10)))
+	child.End(trace.WithTimestamp(at(20)))
+	other.End(trace.WithTimestamp(at(100)))
+	evicted.End(trace.WithTimestamp(at(100)))
+
+	require.Len(t, te.spans, 5)
+	assert.Equal(t, "parent", te.spans[1].Name)
+	assert.Equal(t, 90.0, selfTime(t, te.spans[1]))
+	// The child ending after the span was forgotten is not accounted for.
+	assert.Equal(t, "evicted", te.spans[4].Name)
+	assert.Equal(t, 100.0, selfTime(t, te.spans[4]))
+}
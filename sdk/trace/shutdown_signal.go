@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// defaultSignalShutdownTimeout is the time a TracerProvider is given to
+// flush and shut down when it receives a shutdown signal.
+const defaultSignalShutdownTimeout = 5 * time.Second
+
+// raiseSignal sends sig to the current process, or exits it if sig cannot
+// be sent. It is a variable so tests can avoid terminating.
+var raiseSignal = func(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
+
+// handleShutdownSignals flushes and shuts down p when the process receives
+// one of signals, until stopShutdownSignals is called.
+func (p *TracerProvider) handleShutdownSignals(signals []os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	p.signalDone = make(chan struct{})
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	go func() {
+		defer signal.Stop(ch)
+		select {
+		case <-p.signalDone:
+		case sig := <-ch:
+			ctx, cancel := context.WithTimeout(context.Background(), defaultSignalShutdownTimeout)
+			defer cancel()
+			if err := p.ForceFlush(ctx); err != nil {
+				otel.Handle(err)
+			}
+			if err := p.Shutdown(ctx); err != nil {
+				otel.Handle(err)
+			}
+			// Let the signal terminate the process as it would have.
+			signal.Stop(ch)
+			raiseSignal(sig)
+		}
+	}()
+}
+
+// stopShutdownSignals stops handling the shutdown signals of p, if any.
+func (p *TracerProvider) stopShutdownSignals() {
+	p.signalStopOnce.Do(func() {
+		if p.signalDone != nil {
+			close(p.signalDone)
+		}
+	})
+}
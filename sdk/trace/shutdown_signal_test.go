@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shutdownExporter records the spans it exports and if it is shut down.
+type shutdownExporter struct {
+	mu       sync.Mutex
+	spans    []*SpanSnapshot
+	shutdown bool
+}
+
+func (e *shutdownExporter) ExportSpans(_ context.Context, ss []*SpanSnapshot) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, ss...)
+	return nil
+}
+
+func (e *shutdownExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func TestShutdownOnSignal(t *testing.T) {
+	raised := make(chan os.Signal, 1)
+	orig := raiseSignal
+	raiseSignal = func(sig os.Signal) { raised <- sig }
+	defer func() { raiseSignal = orig }()
+
+	te := &shutdownExporter{}
+	bsp := NewBatchSpanProcessor(te, WithBatchTimeout(time.Hour))
+	tp := NewTracerProvider(WithSpanProcessor(bsp), WithShutdownOnSignal(os.Interrupt))
+	_, span := tp.Tracer("ShutdownOnSignal").Start(context.Background(), "span")
+	span.End()
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot send an interrupt signal: %v", err)
+	}
+
+	select {
+	case sig := <-raised:
+		assert.Equal(t, os.Interrupt, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the signal was not handled")
+	}
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	assert.True(t, te.shutdown)
+	require.Len(t, te.spans, 1)
+	assert.Equal(t, "span", te.spans[0].Name)
+
+	// Shutting down again is a no-op.
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestShutdownStopsSignalHandling(t *testing.T) {
+	tp := NewTracerProvider(WithSyncer(&shutdownExporter{}), WithShutdownOnSignal())
+	assert.NoError(t, tp.Shutdown(context.Background()))
+	assert.NoError(t, tp.Shutdown(context.Background()))
+	select {
+	case <-tp.signalDone:
+	default:
+		t.Fatal("the signals are still handled")
+	}
+}
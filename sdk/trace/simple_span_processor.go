@@ -17,6 +17,7 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
 )
@@ -24,6 +25,11 @@ import (
 // simpleSpanProcessor is a SpanProcessor that synchronously sends all
 // completed Spans to a trace.Exporter immediately.
 type simpleSpanProcessor struct {
+	// exported and exportErrors are accessed atomically and declared first
+	// to ensure their 64-bit alignment.
+	exported     uint64
+	exportErrors uint64
+
 	exporterMu sync.RWMutex
 	exporter   SpanExporter
 	stopOnce   sync.Once
@@ -57,7 +63,10 @@ func (ssp *simpleSpanProcessor) OnEnd(s ReadOnlySpan) {
 	if ssp.exporter != nil && s.SpanContext().TraceFlags().IsSampled() {
 		ss := s.Snapshot()
 		if err := ssp.exporter.ExportSpans(context.Background(), []*SpanSnapshot{ss}); err != nil {
+			atomic.AddUint64(&ssp.exportErrors, 1)
 			otel.Handle(err)
+		} else {
+			atomic.AddUint64(&ssp.exported, 1)
 		}
 	}
 }
@@ -102,3 +111,10 @@ func (ssp *simpleSpanProcessor) Shutdown(ctx context.Context) error {
 func (ssp *simpleSpanProcessor) ForceFlush(context.Context) error {
 	return nil
 }
+
+func (ssp *simpleSpanProcessor) exportCounts() exportCounts {
+	return exportCounts{
+		exported: atomic.LoadUint64(&ssp.exported),
+		errors:   atomic.LoadUint64(&ssp.exportErrors),
+	}
+}
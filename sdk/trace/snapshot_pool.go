@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "sync"
+
+// snapshotPool holds the released SpanSnapshots reused by span.Snapshot.
+var snapshotPool = sync.Pool{
+	New: func() interface{} { return new(SpanSnapshot) },
+}
+
+// newSpanSnapshot returns an empty SpanSnapshot from the pool.
+func newSpanSnapshot() *SpanSnapshot {
+	return snapshotPool.Get().(*SpanSnapshot)
+}
+
+// ReleaseSpanSnapshots returns ss to the pool the SDK creates SpanSnapshots
+// from, reducing the allocations made for each ended span.
+//
+// Releasing is optional, SpanSnapshots that are not released are garbage
+// collected. A SpanExporter may release the SpanSnapshots passed to
+// ExportSpans once it has finished with them, but only if it is their sole
+// user: no reference to the released SpanSnapshots, to their attributes,
+// events, or links, or to ss may be retained. In particular, SpanSnapshots
+// must not be released by an exporter sharing them with other exporters, or
+// by an exporter used with a SpanProcessor that retains them after the export.
+// The SpanSnapshots are reset and must not be used after this is called.
+func ReleaseSpanSnapshots(ss []*SpanSnapshot) {
+	for _, s := range ss {
+		if s == nil {
+			continue
+		}
+		*s = SpanSnapshot{}
+		snapshotPool.Put(s)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestReleaseSpanSnapshots(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te))
+	_, span := tp.Tracer("ReleaseSpanSnapshots").Start(context.Background(), "span")
+	span.SetAttributes(attribute.String("key", "value"))
+	span.End()
+
+	ss := te.Spans()
+	assert.Len(t, ss, 1)
+	assert.Equal(t, "span", ss[0].Name)
+
+	ReleaseSpanSnapshots(append(ss, nil))
+	assert.Equal(t, SpanSnapshot{}, *ss[0])
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// snapshotSpan is a ReadOnlySpan that reports the information contained in a
+// SpanSnapshot instead of the span it was created from. It is used by
+// SpanProcessors that modify the span data passed to the next SpanProcessor
+// in a chain without modifying the ended span itself.
+type snapshotSpan struct {
+	ReadOnlySpan
+
+	snapshot *SpanSnapshot
+}
+
+var _ ReadOnlySpan = snapshotSpan{}
+
+// newSnapshotSpan returns a ReadOnlySpan reporting the data of ss. All
+// methods not related to the span data are delegated to s.
+func newSnapshotSpan(s ReadOnlySpan, ss *SpanSnapshot) ReadOnlySpan {
+	return snapshotSpan{ReadOnlySpan: s, snapshot: ss}
+}
+
+func (s snapshotSpan) Name() string                     { return s.snapshot.Name }
+func (s snapshotSpan) SpanContext() trace.SpanContext   { return s.snapshot.SpanContext }
+func (s snapshotSpan) Parent() trace.SpanContext        { return s.snapshot.Parent }
+func (s snapshotSpan) SpanKind() trace.SpanKind         { return s.snapshot.SpanKind }
+func (s snapshotSpan) StartTime() time.Time             { return s.snapshot.StartTime }
+func (s snapshotSpan) EndTime() time.Time               { return s.snapshot.EndTime }
+func (s snapshotSpan) Attributes() []attribute.KeyValue { return s.snapshot.Attributes }
+func (s snapshotSpan) Links() []trace.Link              { return s.snapshot.Links }
+func (s snapshotSpan) Events() []Event                  { return s.snapshot.MessageEvents }
+func (s snapshotSpan) StatusCode() codes.Code           { return s.snapshot.StatusCode }
+func (s snapshotSpan) StatusMessage() string            { return s.snapshot.StatusMessage }
+func (s snapshotSpan) Resource() *resource.Resource     { return s.snapshot.Resource }
+func (s snapshotSpan) InstrumentationLibrary() instrumentation.Library {
+	return s.snapshot.InstrumentationLibrary
+}
+
+// Snapshot returns a copy of the SpanSnapshot this span reports so
+// subsequent modifications do not alter the data seen by other readers.
+func (s snapshotSpan) Snapshot() *SpanSnapshot {
+	ss := *s.snapshot
+	return &ss
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// globalTracerPackage is the package of the global Tracer delegating its
+// Start calls to the Tracers of this SDK.
+const globalTracerPackage = "go.opentelemetry.io/otel/internal/global."
+
+// sourceLocation returns the code semantic convention attributes of the
+// caller of the function calling sourceLocation, skipping the global Tracer.
+// It returns nil if the caller cannot be resolved.
+func sourceLocation() []attribute.KeyValue {
+	var pcs [8]uintptr
+	// Skip runtime.Callers, sourceLocation and its caller.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, globalTracerPackage) {
+			if frame.Function == "" {
+				return nil
+			}
+			namespace, function := splitFunctionName(frame.Function)
+			return []attribute.KeyValue{
+				semconv.CodeNamespaceKey.String(namespace),
+				semconv.CodeFunctionKey.String(function),
+				semconv.CodeFilepathKey.String(frame.File),
+				semconv.CodeLineNumberKey.Int(frame.Line),
+			}
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// splitFunctionName splits the fully qualified name of a function, e.g.
+// "go.opentelemetry.io/otel/sdk/trace.(*tracer).Start", into its package
+// and its name within the package.
+func splitFunctionName(name string) (string, string) {
+	pkgStart := strings.LastIndex(name, "/") + 1
+	dot := strings.Index(name[pkgStart:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:pkgStart+dot], name[pkgStart+dot+1:]
+}
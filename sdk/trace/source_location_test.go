@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithSourceLocation(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(nil))
+	tr := tp.Tracer("SourceLocation")
+
+	_, file, line, _ := runtime.Caller(0)
+	_, span := tr.Start(context.Background(), "span", trace.WithSourceLocation())
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.CodeNamespaceKey.String("go.opentelemetry.io/otel/sdk/trace"),
+		semconv.CodeFunctionKey.String("TestWithSourceLocation"),
+		semconv.CodeFilepathKey.String(file),
+		semconv.CodeLineNumberKey.Int(line + 1),
+	}, got.Attributes)
+
+	require.NoError(t, te.Shutdown(context.Background()))
+	_, span = tr.Start(context.Background(), "span")
+	got, err = endSpan(te, span)
+	require.NoError(t, err)
+	assert.Empty(t, got.Attributes)
+}
+
+func TestSplitFunctionName(t *testing.T) {
+	for _, test := range []struct {
+		name, namespace, function string
+	}{
+		{"main.main", "main", "main"},
+		{"go.opentelemetry.io/otel/sdk/trace.(*tracer).Start", "go.opentelemetry.io/otel/sdk/trace", "(*tracer).Start"},
+		{"example.com/pkg.Func.func1", "example.com/pkg", "Func.func1"},
+		{"nodot", "", "nodot"},
+	} {
+		namespace, function := splitFunctionName(test.name)
+		assert.Equal(t, test.namespace, namespace, test.name)
+		assert.Equal(t, test.function, function, test.name)
+	}
+}
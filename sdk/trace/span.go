@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/semconv"
@@ -233,8 +235,13 @@ func (s *span) End(options ...trace.SpanOption) {
 	} else {
 		s.endTime = config.Timestamp
 	}
+	if redact := s.tracer.provider.spanNameRedactor; redact != nil {
+		s.name = redact(s.name)
+	}
 	s.mu.Unlock()
 
+	atomic.AddUint64(&s.tracer.provider.spanCounts.ended, 1)
+
 	sps, ok := s.tracer.provider.spanProcessors.Load().(spanProcessorStates)
 	mustExportOrProcess := ok && len(sps) > 0
 	if mustExportOrProcess {
@@ -242,6 +249,7 @@ func (s *span) End(options ...trace.SpanOption) {
 			sp.sp.OnEnd(s)
 		}
 	}
+	observe(s.tracer.provider.endObservers, s)
 }
 
 // RecordError will record err as a span event for this span. An additional call to
@@ -292,6 +300,10 @@ func (s *span) addEvent(name string, o ...trace.EventOption) {
 		discarded = len(c.Attributes) - s.spanLimits.AttributePerEventCountLimit
 		c.Attributes = c.Attributes[:s.spanLimits.AttributePerEventCountLimit]
 	}
+	var dropped int
+	c.Attributes, dropped = s.limitAttrKeys(c.Attributes)
+	discarded += dropped
+	c.Attributes = truncateAttrs(s.spanLimits.AttributeValueLengthLimit, c.Attributes)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -355,7 +367,7 @@ func (s *span) EndTime() time.Time {
 func (s *span) Attributes() []attribute.KeyValue {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.attributes.evictList.Len() == 0 {
+	if s.attributes.len() == 0 {
 		return []attribute.KeyValue{}
 	}
 	return s.attributes.toKeyValue()
@@ -365,7 +377,7 @@ func (s *span) Attributes() []attribute.KeyValue {
 func (s *span) Links() []trace.Link {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if len(s.links.queue) == 0 {
+	if s.links.len() == 0 {
 		return []trace.Link{}
 	}
 	return s.interfaceArrayToLinksArray()
@@ -375,7 +387,7 @@ func (s *span) Links() []trace.Link {
 func (s *span) Events() []Event {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if len(s.messageEvents.queue) == 0 {
+	if s.messageEvents.len() == 0 {
 		return []Event{}
 	}
 	return s.interfaceArrayToMessageEventArray()
@@ -411,8 +423,10 @@ func (s *span) Resource() *resource.Resource {
 	return s.resource
 }
 
+// addLink adds link to this span. Links with an invalid SpanContext are
+// dropped as they do not reference any span.
 func (s *span) addLink(link trace.Link) {
-	if !s.IsRecording() {
+	if !s.IsRecording() || !link.SpanContext.IsValid() {
 		return
 	}
 	s.mu.Lock()
@@ -423,6 +437,10 @@ func (s *span) addLink(link trace.Link) {
 		link.DroppedAttributeCount = len(link.Attributes) - s.spanLimits.AttributePerLinkCountLimit
 		link.Attributes = link.Attributes[:s.spanLimits.AttributePerLinkCountLimit]
 	}
+	var dropped int
+	link.Attributes, dropped = s.limitAttrKeys(link.Attributes)
+	link.DroppedAttributeCount += dropped
+	link.Attributes = truncateAttrs(s.spanLimits.AttributeValueLengthLimit, link.Attributes)
 
 	s.links.add(link)
 }
@@ -430,7 +448,7 @@ func (s *span) addLink(link trace.Link) {
 // Snapshot creates a snapshot representing the current state of the span as an
 // export.SpanSnapshot and returns a pointer to it.
 func (s *span) Snapshot() *SpanSnapshot {
-	var sd SpanSnapshot
+	sd := newSpanSnapshot()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -446,19 +464,19 @@ func (s *span) Snapshot() *SpanSnapshot {
 	sd.StatusCode = s.statusCode
 	sd.StatusMessage = s.statusMessage
 
-	if s.attributes.evictList.Len() > 0 {
+	if s.attributes.len() > 0 {
 		sd.Attributes = s.attributes.toKeyValue()
 		sd.DroppedAttributeCount = s.attributes.droppedCount
 	}
-	if len(s.messageEvents.queue) > 0 {
+	if s.messageEvents.len() > 0 {
 		sd.MessageEvents = s.interfaceArrayToMessageEventArray()
 		sd.DroppedMessageEventCount = s.messageEvents.droppedCount
 	}
-	if len(s.links.queue) > 0 {
+	if s.links.len() > 0 {
 		sd.Links = s.interfaceArrayToLinksArray()
 		sd.DroppedLinkCount = s.links.droppedCount
 	}
-	return &sd
+	return sd
 }
 
 func (s *span) interfaceArrayToLinksArray() []trace.Link {
@@ -478,15 +496,124 @@ func (s *span) interfaceArrayToMessageEventArray() []Event {
 }
 
 func (s *span) copyToCappedAttributes(attributes ...attribute.KeyValue) {
+	var errs []error
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	for _, a := range attributes {
 		// Ensure attributes conform to the specification:
 		// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.0.1/specification/common/common.md#attributes
-		if a.Valid() {
-			s.attributes.add(a)
+		if err := validateAttribute(a); err != nil {
+			s.attributes.droppedCount++
+			errs = append(errs, err)
+			continue
+		}
+		a, ok := s.limitAttrKey(a)
+		if !ok {
+			s.attributes.droppedCount++
+			continue
+		}
+		a, _ = truncateAttr(s.spanLimits.AttributeValueLengthLimit, a)
+		s.attributes.add(a)
+	}
+	s.mu.Unlock()
+
+	// Report outside of the lock, the error handler may use the span.
+	for _, err := range errs {
+		otel.Handle(err)
+	}
+}
+
+// truncateAttr returns kv with its value truncated to limit characters if it
+// is a string value longer than that, and if it was truncated. If limit is
+// not positive, kv is returned unchanged.
+func truncateAttr(limit int, kv attribute.KeyValue) (attribute.KeyValue, bool) {
+	if limit <= 0 || kv.Value.Type() != attribute.STRING {
+		return kv, false
+	}
+	v := kv.Value.AsString()
+	if len(v) <= limit {
+		return kv, false
+	}
+	var n int
+	for i := range v {
+		if n == limit {
+			return kv.Key.String(v[:i]), true
+		}
+		n++
+	}
+	return kv, false
+}
+
+// truncateAttrs returns attrs with all string values truncated to limit
+// characters. The passed attrs are not modified.
+func truncateAttrs(limit int, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if limit <= 0 {
+		return attrs
+	}
+	var truncated []attribute.KeyValue
+	for i, a := range attrs {
+		t, ok := truncateAttr(limit, a)
+		if !ok {
+			continue
+		}
+		if truncated == nil {
+			truncated = make([]attribute.KeyValue, len(attrs))
+			copy(truncated, attrs)
+		}
+		truncated[i] = t
+	}
+	if truncated == nil {
+		return attrs
+	}
+	return truncated
+}
+
+// limitAttrKey returns kv with its key truncated to the
+// AttributeKeyLengthLimit of s if it is longer than that, and if kv is kept.
+// It is not kept if its key is too long and DropLongAttributeKeys is true.
+func (s *span) limitAttrKey(kv attribute.KeyValue) (attribute.KeyValue, bool) {
+	limit := s.spanLimits.AttributeKeyLengthLimit
+	if limit <= 0 || len(kv.Key) <= limit {
+		return kv, true
+	}
+	var n int
+	for i := range kv.Key {
+		if n == limit {
+			if s.spanLimits.DropLongAttributeKeys {
+				return kv, false
+			}
+			kv.Key = kv.Key[:i]
+			return kv, true
+		}
+		n++
+	}
+	return kv, true
+}
+
+// limitAttrKeys returns attrs with all keys limited by limitAttrKey, and the
+// number of attributes that were not kept. The passed attrs are not
+// modified.
+func (s *span) limitAttrKeys(attrs []attribute.KeyValue) ([]attribute.KeyValue, int) {
+	if s.spanLimits.AttributeKeyLengthLimit <= 0 {
+		return attrs, 0
+	}
+	var limited []attribute.KeyValue
+	for i, a := range attrs {
+		l, ok := s.limitAttrKey(a)
+		if limited == nil {
+			if ok && l.Key == a.Key {
+				continue
+			}
+			limited = make([]attribute.KeyValue, i, len(attrs))
+			copy(limited, attrs[:i])
+		}
+		if ok {
+			limited = append(limited, l)
 		}
 	}
+	if limited == nil {
+		return attrs, 0
+	}
+	return limited, len(attrs) - len(limited)
 }
 
 func (s *span) addChild() {
@@ -524,13 +651,8 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 		sid = provider.idGenerator.NewSpanID(ctx, tid)
 	}
 
-	spanLimits := provider.spanLimits
-	span.attributes = newAttributesMap(spanLimits.AttributeCountLimit)
-	span.messageEvents = newEvictedQueue(spanLimits.EventCountLimit)
-	span.links = newEvictedQueue(spanLimits.LinkCountLimit)
-	span.spanLimits = spanLimits
-
-	samplingResult := provider.sampler.ShouldSample(SamplingParameters{
+	sampler := tr.samplerOf()
+	samplingResult := sampler.ShouldSample(SamplingParameters{
 		ParentContext: ctx,
 		TraceID:       tid,
 		Name:          name,
@@ -538,11 +660,23 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 		Attributes:    o.Attributes,
 		Links:         o.Links,
 	})
+	if psc.IsDebug() && !isSampled(samplingResult) {
+		// Debugged traces are always sampled.
+		samplingResult.Decision = RecordAndSample
+	}
+	if o.Sampled != nil {
+		// The decision is overridden for this span only.
+		if *o.Sampled {
+			samplingResult.Decision = RecordAndSample
+		} else {
+			samplingResult.Decision = Drop
+		}
+	}
 
 	scc := trace.SpanContextConfig{
 		TraceID:    tid,
 		SpanID:     sid,
-		TraceState: samplingResult.Tracestate,
+		TraceState: traceStateOf(samplingResult, psc),
 	}
 	if isSampled(samplingResult) {
 		scc.TraceFlags = psc.TraceFlags() | trace.FlagsSampled
@@ -552,9 +686,24 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 	span.spanContext = trace.NewSpanContext(scc)
 
 	if !isRecording(samplingResult) {
+		// Spans that are not recorded never hold attributes, events, or
+		// links: do not allocate their storage.
 		return span
 	}
 
+	spanLimits := provider.spanLimits
+	span.attributes = newAttributesMap(spanLimits.AttributeCountLimit)
+	span.attributes.bytesLimit = spanLimits.AttributeTotalBytesLimit
+	if n := o.AttributeCapacity; n > 0 {
+		if n > spanLimits.AttributeCountLimit {
+			n = spanLimits.AttributeCountLimit
+		}
+		span.attributes.sizeHint = n
+	}
+	span.messageEvents = newEvictedQueue(spanLimits.EventCountLimit)
+	span.links = newEvictedQueue(spanLimits.LinkCountLimit)
+	span.spanLimits = spanLimits
+
 	startTime := o.Timestamp
 	if startTime.IsZero() {
 		startTime = time.Now()
@@ -567,7 +716,14 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 	span.resource = provider.resource
 	span.instrumentationLibrary = tr.instrumentationLibrary
 
+	span.SetAttributes(provider.defaultSpanAttributes...)
 	span.SetAttributes(samplingResult.Attributes...)
+	if provider.recordSamplerInfo {
+		span.SetAttributes(samplerInfo(sampler)...)
+	}
+	if provider.recordBaggage {
+		span.SetAttributes(baggageAttributes(ctx)...)
+	}
 
 	return span
 }
@@ -615,3 +771,56 @@ type SpanSnapshot struct {
 	// provide instrumentation.
 	InstrumentationLibrary instrumentation.Library
 }
+
+// Clone returns a deep copy of s. The attributes, events, and links of the
+// returned SpanSnapshot do not share memory with the ones of s, they can be
+// modified without affecting s or any other user of s. The Resource is not
+// copied as it is immutable.
+func (s *SpanSnapshot) Clone() *SpanSnapshot {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Attributes = cloneAttributes(s.Attributes)
+
+	// The attributes of all events and links share a single allocation.
+	n := 0
+	for _, e := range s.MessageEvents {
+		n += len(e.Attributes)
+	}
+	for _, l := range s.Links {
+		n += len(l.Attributes)
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+	sub := func(kvs []attribute.KeyValue) []attribute.KeyValue {
+		if kvs == nil {
+			return nil
+		}
+		i := len(attrs)
+		attrs = append(attrs, kvs...)
+		return attrs[i:len(attrs):len(attrs)]
+	}
+
+	if s.MessageEvents != nil {
+		c.MessageEvents = make([]Event, len(s.MessageEvents))
+		for i, e := range s.MessageEvents {
+			e.Attributes = sub(e.Attributes)
+			c.MessageEvents[i] = e
+		}
+	}
+	if s.Links != nil {
+		c.Links = make([]trace.Link, len(s.Links))
+		for i, l := range s.Links {
+			l.Attributes = sub(l.Attributes)
+			c.Links[i] = l
+		}
+	}
+	return &c
+}
+
+func cloneAttributes(kvs []attribute.KeyValue) []attribute.KeyValue {
+	if kvs == nil {
+		return nil
+	}
+	return append(make([]attribute.KeyValue, 0, len(kvs)), kvs...)
+}
@@ -30,6 +30,10 @@ type SpanExporter interface {
 	// calls this function will not implement any retry logic. All errors
 	// returned by this function are considered unrecoverable and will be
 	// reported to a configured error Handler.
+	//
+	// The SpanSnapshots must not be modified. Exporters that do not retain
+	// them after returning may pass them to ReleaseSpanSnapshots so they are
+	// reused by the SDK.
 	ExportSpans(ctx context.Context, ss []*SpanSnapshot) error
 	// Shutdown notifies the exporter of a pending halt to operations. The
 	// exporter is expected to preform any cleanup or synchronization it
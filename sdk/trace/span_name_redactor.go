@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RedactedID replaces the identifiers redacted by RedactSpanNameIDs.
+const RedactedID = "{id}"
+
+var (
+	// uuidPattern matches UUIDs in their canonical textual form.
+	uuidPattern = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	// hexIDPattern matches hexadecimal identifiers of at least 16 digits,
+	// e.g. hashes, object IDs or tokens.
+	hexIDPattern = regexp.MustCompile(`\b[0-9a-fA-F]{16,}\b`)
+)
+
+// RedactSpanNameIDs returns name with the common identifier patterns it
+// contains replaced by RedactedID. These are UUIDs, e.g.
+// "123e4567-e89b-12d3-a456-426614174000", hexadecimal identifiers of at
+// least 16 digits, and numeric path segments, e.g. "/users/42".
+//
+// It is intended to be used with WithSpanNameRedactor:
+//
+//	// "GET /users/42/orders" is exported as "GET /users/{id}/orders".
+//	tp := NewTracerProvider(WithSpanNameRedactor(RedactSpanNameIDs))
+func RedactSpanNameIDs(name string) string {
+	name = uuidPattern.ReplaceAllLiteralString(name, RedactedID)
+	name = hexIDPattern.ReplaceAllLiteralString(name, RedactedID)
+	if !strings.Contains(name, "/") {
+		return name
+	}
+
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		// The first segment is not part of the path, e.g. "GET ".
+		if i > 0 && isNumeric(segment) {
+			segments[i] = RedactedID
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isNumeric returns whether s is a non empty sequence of decimal digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
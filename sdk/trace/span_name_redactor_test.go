@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSpanNameIDs(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"GET /users", "GET /users"},
+		{"GET /users/42", "GET /users/{id}"},
+		{"GET /users/42/orders/7", "GET /users/{id}/orders/{id}"},
+		{"GET /api/v1/users/", "GET /api/v1/users/"},
+		{"/orders/123e4567-e89b-12d3-a456-426614174000/items", "/orders/{id}/items"},
+		{"token 0123456789abcdef0123", "token {id}"},
+		{"cafe", "cafe"},
+		{"HTTP/1.1", "HTTP/1.1"},
+		{"1234", "1234"},
+	} {
+		assert.Equal(t, test.want, RedactSpanNameIDs(test.name), test.name)
+	}
+}
+
+func TestWithSpanNameRedactor(t *testing.T) {
+	te := NewTestExporter()
+	var ended string
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithSpanNameRedactor(strings.ToUpper),
+		WithSpanEndObserver(func(s ReadOnlySpan) { ended = s.Name() }),
+	)
+
+	_, span := tp.Tracer("SpanNameRedactor").Start(context.Background(), "GET /users/42")
+	span.SetName("get /users/42")
+	span.End()
+
+	require.Len(t, te.Spans(), 1)
+	assert.Equal(t, "GET /USERS/42", te.Spans()[0].Name)
+	assert.Equal(t, "GET /USERS/42", ended)
+}
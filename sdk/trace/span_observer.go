@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+)
+
+// SpanObserver is a callback observing the lifecycle of the spans created by
+// a TracerProvider. It is lighter than a SpanProcessor when the spans only
+// need to be read, e.g. to keep live span counters.
+//
+// The observed span is read-only, and the SpanObserver must not retain it
+// beyond the call. A SpanObserver is called synchronously by the goroutine
+// starting or ending the span and therefore needs to be safe to call
+// concurrently and to return quickly.
+type SpanObserver func(ReadOnlySpan)
+
+// observedSpan restricts a span to its ReadOnlySpan methods so a SpanObserver
+// cannot type assert and mutate it.
+type observedSpan struct {
+	ReadOnlySpan
+}
+
+// observe calls all observers with s. A panic of an observer is recovered and
+// reported to the global error handler, and the remaining observers are still
+// called.
+func observe(observers []SpanObserver, s ReadOnlySpan) {
+	if len(observers) == 0 {
+		return
+	}
+	ro := observedSpan{s}
+	for _, o := range observers {
+		callObserver(o, ro)
+	}
+}
+
+func callObserver(o SpanObserver, s ReadOnlySpan) {
+	defer func() {
+		if r := recover(); r != nil {
+			otel.Handle(fmt.Errorf("span observer panic: %v", r))
+		}
+	}()
+	o(s)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanObservers(t *testing.T) {
+	var started, ended int64
+	var startNames, endNames []string
+	tp := NewTracerProvider(
+		WithSpanStartObserver(func(s ReadOnlySpan) {
+			atomic.AddInt64(&started, 1)
+			startNames = append(startNames, s.Name())
+		}),
+		WithSpanEndObserver(func(s ReadOnlySpan) {
+			atomic.AddInt64(&ended, 1)
+			assert.False(t, s.EndTime().IsZero())
+			endNames = append(endNames, s.Name())
+		}),
+	)
+	tr := tp.Tracer("SpanObservers")
+
+	_, span := tr.Start(context.Background(), "span0")
+	assert.Equal(t, int64(1), atomic.LoadInt64(&started))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&ended))
+	span.End()
+	_, span = tr.Start(context.Background(), "span1")
+	span.End()
+
+	assert.Equal(t, int64(2), atomic.LoadInt64(&started))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&ended))
+	assert.Equal(t, []string{"span0", "span1"}, startNames)
+	assert.Equal(t, []string{"span0", "span1"}, endNames)
+}
+
+func TestSpanObserversNonRecording(t *testing.T) {
+	var calls int
+	observer := func(ReadOnlySpan) { calls++ }
+	tp := NewTracerProvider(
+		WithSampler(NeverSample()),
+		WithSpanStartObserver(observer),
+		WithSpanEndObserver(observer),
+	)
+	_, span := tp.Tracer("SpanObservers").Start(context.Background(), "span")
+	span.End()
+	assert.Equal(t, 0, calls)
+}
+
+func TestSpanObserversCannotMutateSpan(t *testing.T) {
+	var mutable bool
+	tp := NewTracerProvider(WithSpanStartObserver(func(s ReadOnlySpan) {
+		_, ok := s.(trace.Span)
+		mutable = mutable || ok
+		_, ok = s.(ReadWriteSpan)
+		mutable = mutable || ok
+	}))
+	_, span := tp.Tracer("SpanObservers").Start(context.Background(), "span")
+	span.End()
+	assert.False(t, mutable)
+}
+
+func TestSpanObserversPanicRecovered(t *testing.T) {
+	handler.Reset()
+	var called bool
+	tp := NewTracerProvider(
+		WithSpanEndObserver(func(ReadOnlySpan) { panic("observer failure") }),
+		WithSpanEndObserver(func(ReadOnlySpan) { called = true }),
+	)
+	_, span := tp.Tracer("SpanObservers").Start(context.Background(), "span")
+	require.NotPanics(t, func() { span.End() })
+
+	assert.True(t, called, "observer after panicking observer not called")
+	require.Len(t, handler.errs, 1)
+	assert.Contains(t, handler.errs[0].Error(), "observer failure")
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "sync/atomic"
+
+// Stats are the counts of the spans handled by a TracerProvider. They are
+// meant to confirm that a tracing pipeline is healthy.
+type Stats struct {
+	// SpansStarted is the number of spans started by the Tracers of the
+	// TracerProvider.
+	SpansStarted uint64
+	// SpansSampled is the number of started spans that were sampled.
+	SpansSampled uint64
+	// SpansEnded is the number of recording spans that were ended.
+	SpansEnded uint64
+	// SpansDropped is the number of spans dropped by a BatchSpanProcessor
+	// because its queue was full, or by a channel SpanProcessor because its
+	// channel was full.
+	SpansDropped uint64
+	// SpansExported is the number of spans passed to SpanExporters that
+	// did not return an error, or sent by channel SpanProcessors.
+	SpansExported uint64
+	// ExportErrors is the number of calls to SpanExporters that returned an
+	// error.
+	ExportErrors uint64
+}
+
+// spanCounts are the span counters of a TracerProvider.
+type spanCounts struct {
+	started uint64
+	sampled uint64
+	ended   uint64
+}
+
+// exportCounts are the counters of a SpanProcessor exporting spans.
+type exportCounts struct {
+	dropped  uint64
+	exported uint64
+	errors   uint64
+}
+
+func (c *exportCounts) add(o exportCounts) {
+	c.dropped += o.dropped
+	c.exported += o.exported
+	c.errors += o.errors
+}
+
+// exportCounter is implemented by the SpanProcessors of this package that
+// export spans, or wrap a SpanProcessor exporting spans, to report their
+// counts to the Stats of a TracerProvider.
+type exportCounter interface {
+	exportCounts() exportCounts
+}
+
+// exportCountsOf returns the export counts of sp, or none if sp does not
+// report them.
+func exportCountsOf(sp SpanProcessor) exportCounts {
+	if c, ok := sp.(exportCounter); ok {
+		return c.exportCounts()
+	}
+	return exportCounts{}
+}
+
+// Stats returns a snapshot of the counts of the spans handled by p.
+//
+// The export counts include only the BatchSpanProcessors,
+// SimpleSpanProcessors and channel SpanProcessors currently registered with
+// p, directly or within the SpanProcessors of this package wrapping other
+// SpanProcessors.
+func (p *TracerProvider) Stats() Stats {
+	var ec exportCounts
+	if spss, ok := p.spanProcessors.Load().(spanProcessorStates); ok {
+		for _, sps := range spss {
+			ec.add(exportCountsOf(sps.sp))
+		}
+	}
+	return Stats{
+		SpansStarted:  atomic.LoadUint64(&p.spanCounts.started),
+		SpansSampled:  atomic.LoadUint64(&p.spanCounts.sampled),
+		SpansEnded:    atomic.LoadUint64(&p.spanCounts.ended),
+		SpansDropped:  ec.dropped,
+		SpansExported: ec.exported,
+		ExportErrors:  ec.errors,
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type failingExporter struct{ testExporter }
+
+func (*failingExporter) ExportSpans(context.Context, []*sdktrace.SpanSnapshot) error {
+	return errors.New("export failed")
+}
+
+func TestTracerProviderStats(t *testing.T) {
+	te := &testExporter{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(te),
+		sdktrace.WithSpanProcessor(sdktrace.NewRootSpanProcessor(
+			sdktrace.NewSimpleSpanProcessor(&failingExporter{}),
+		)),
+	)
+	assert.Equal(t, sdktrace.Stats{}, tp.Stats())
+
+	ctx, root := tp.Tracer("Stats").Start(context.Background(), "root")
+	_, child := tp.Tracer("Stats").Start(ctx, "child")
+	_, unsampled := tp.Tracer("Stats", sdktrace.WithTracerSampler(sdktrace.NeverSample())).Start(context.Background(), "unsampled")
+	assert.Equal(t, sdktrace.Stats{
+		SpansStarted: 3,
+		SpansSampled: 2,
+	}, tp.Stats())
+
+	child.End()
+	root.End()
+	unsampled.End()
+	assert.Equal(t, sdktrace.Stats{
+		SpansStarted:  3,
+		SpansSampled:  2,
+		SpansEnded:    2,
+		SpansExported: 2,
+		ExportErrors:  1,
+	}, tp.Stats())
+}
+
+func TestTracerProviderStatsBatchSpanProcessor(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(&testExporter{}))
+	tr := tp.Tracer("Stats")
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	// Shutdown, contrary to ForceFlush, exports the queued spans.
+	assert.NoError(t, tp.Shutdown(context.Background()))
+
+	stats := tp.Stats()
+	assert.Equal(t, uint64(3), stats.SpansEnded)
+	assert.Equal(t, uint64(3), stats.SpansExported)
+	assert.Equal(t, uint64(0), stats.SpansDropped)
+	assert.Equal(t, uint64(0), stats.ExportErrors)
+}
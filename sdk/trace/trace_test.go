@@ -28,6 +28,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/oteltest"
 	"go.opentelemetry.io/otel/semconv"
@@ -225,6 +226,35 @@ func TestSetName(t *testing.T) {
 	}
 }
 
+func TestSpanContextOfLiveSpan(t *testing.T) {
+	ts, err := trace.TraceStateFromKeyValues(kv1)
+	require.NoError(t, err)
+	parent := sc.WithTraceState(ts).WithRemote(true)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+
+	tp := NewTracerProvider()
+	_, span := tp.Tracer("SpanContext").Start(ctx, "span")
+	got := span.SpanContext()
+	assert.True(t, got.IsValid())
+	assert.True(t, got.IsSampled())
+	assert.False(t, got.IsRemote())
+	assert.Equal(t, parent.TraceID(), got.TraceID())
+	assert.NotEqual(t, parent.SpanID(), got.SpanID())
+	assert.Equal(t, ts.String(), got.TraceState().String())
+
+	// The SpanContext must remain usable once the span stops recording.
+	span.End()
+	require.False(t, span.IsRecording())
+	assert.True(t, got.Equal(span.SpanContext()))
+
+	// A non-recording span only carrying a sampled SpanContext still
+	// returns it.
+	nonRecording := trace.SpanFromContext(ctx)
+	require.False(t, nonRecording.IsRecording())
+	assert.True(t, nonRecording.SpanContext().IsValid())
+	assert.True(t, nonRecording.SpanContext().IsSampled())
+}
+
 func TestSpanIsRecording(t *testing.T) {
 	t.Run("while Span active", func(t *testing.T) {
 		for name, tc := range map[string]struct {
@@ -436,6 +466,36 @@ func TestSetSpanAttributes(t *testing.T) {
 	}
 }
 
+func TestSetSpanAttributesOverwrite(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "SpanAttributesOverwrite")
+	span.SetAttributes(attribute.String("key", "value1"), attribute.Int("other", 1))
+	span.SetAttributes(attribute.String("key", "value2"))
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &SpanSnapshot{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		Parent: sc.WithRemote(true),
+		Name:   "span0",
+		Attributes: []attribute.KeyValue{
+			attribute.Int("other", 1),
+			attribute.String("key", "value2"),
+		},
+		SpanKind:               trace.SpanKindInternal,
+		InstrumentationLibrary: instrumentation.Library{Name: "SpanAttributesOverwrite"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("SetSpanAttributesOverwrite: -got +want %s", diff)
+	}
+}
+
 func TestSamplerAttributesLocalChildSpan(t *testing.T) {
 	sampler := &testSampler{prefix: "span", t: t}
 	te := NewTestExporter()
@@ -458,6 +518,189 @@ func TestSamplerAttributesLocalChildSpan(t *testing.T) {
 	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 1)}, gotSpan1.Attributes)
 }
 
+func TestRecordSamplerInfo(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	startSpan(tp, "RecordSamplerInfo").End()
+	require.Len(t, te.Spans(), 1)
+	assert.Empty(t, te.Spans()[0].Attributes, "sampler info recorded by default")
+
+	te = NewTestExporter()
+	tp = NewTracerProvider(
+		WithSampler(TraceIDRatioBased(1)),
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+		WithRecordSamplerInfo(),
+	)
+	startSpan(tp, "RecordSamplerInfo").End()
+	require.Len(t, te.Spans(), 1)
+	want := []attribute.KeyValue{SamplerNameKey.String("AlwaysOnSampler")}
+	assert.Equal(t, want, te.Spans()[0].Attributes)
+
+	te = NewTestExporter()
+	tp = NewTracerProvider(
+		WithSampler(TraceIDRatioBased(0.5)),
+		WithIDGenerator(&testIDGenerator{traceID: 1, spanID: 1}),
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+		WithRecordSamplerInfo(),
+	)
+	startSpan(tp, "RecordSamplerInfo").End()
+	require.Len(t, te.Spans(), 1)
+	want = []attribute.KeyValue{
+		SamplerNameKey.String("TraceIDRatioBased"),
+		SamplerParamKey.String("0.5"),
+	}
+	assert.Equal(t, want, te.Spans()[0].Attributes)
+}
+
+func TestRecordBaggage(t *testing.T) {
+	ctx := baggage.ContextWithValues(context.Background(),
+		attribute.String("user", "alice"),
+		attribute.Int("tenant", 7),
+	)
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	_, span := tp.Tracer("RecordBaggage").Start(ctx, "span")
+	span.End()
+	require.Len(t, te.Spans(), 1)
+	assert.Empty(t, te.Spans()[0].Attributes, "baggage recorded by default")
+
+	te = NewTestExporter()
+	tp = NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()), WithRecordBaggage())
+	_, span = tp.Tracer("RecordBaggage").Start(ctx, "span")
+	_, child := tp.Tracer("RecordBaggage").Start(context.Background(), "no baggage")
+	child.End()
+	span.End()
+	require.Len(t, te.Spans(), 2)
+	assert.Empty(t, te.Spans()[0].Attributes)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("baggage.user", "alice"),
+		attribute.Int("baggage.tenant", 7),
+	}, te.Spans()[1].Attributes)
+}
+
+func TestAttributeTotalBytesLimit(t *testing.T) {
+	te := NewTestExporter()
+	limits := SpanLimits{AttributeTotalBytesLimit: 12, AttributeValueLengthLimit: 8}
+	tp := NewTracerProvider(WithSpanLimits(limits), WithSyncer(te), WithResource(resource.Empty()))
+
+	_, span := tp.Tracer("AttributeTotalBytesLimit").Start(context.Background(), "span")
+	span.SetAttributes(
+		// Truncated to 8 bytes before being accounted for.
+		attribute.String("long", "abcdefghij"),
+		attribute.String("short", "ab"),
+		attribute.Int("int", 1),
+		attribute.Bool("bool", true),
+	)
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("long", "abcdefgh"),
+		attribute.String("short", "ab"),
+		attribute.Bool("bool", true),
+	}, got.Attributes)
+	assert.Equal(t, 1, got.DroppedAttributeCount)
+}
+
+func TestAttributeValueLengthLimit(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{AttributeValueLengthLimit: 3}), WithSyncer(te), WithResource(resource.Empty()))
+
+	linkAttrs := []attribute.KeyValue{attribute.String("link", "abcdef")}
+	_, span := tp.Tracer("AttributeValueLengthLimit").Start(
+		context.Background(),
+		"span",
+		trace.WithLinks(trace.Link{SpanContext: sc, Attributes: linkAttrs}),
+	)
+	span.SetAttributes(
+		attribute.String("long", "abcdef"),
+		attribute.String("short", "ab"),
+		attribute.String("multibyte", "äöüß"),
+		attribute.Int("int", 123456),
+	)
+	eventAttrs := []attribute.KeyValue{attribute.String("event", "abcdef")}
+	span.AddEvent("event", trace.WithAttributes(eventAttrs...))
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("long", "abc"),
+		attribute.String("short", "ab"),
+		attribute.String("multibyte", "äöü"),
+		attribute.Int("int", 123456),
+	}, got.Attributes)
+	require.Len(t, got.MessageEvents, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("event", "abc")}, got.MessageEvents[0].Attributes)
+	require.Len(t, got.Links, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("link", "abc")}, got.Links[0].Attributes)
+
+	// The passed attributes are not modified.
+	assert.Equal(t, "abcdef", eventAttrs[0].Value.AsString())
+	assert.Equal(t, "abcdef", linkAttrs[0].Value.AsString())
+}
+
+func TestAttributeKeyLengthLimit(t *testing.T) {
+	for _, drop := range []bool{false, true} {
+		te := NewTestExporter()
+		limits := SpanLimits{AttributeKeyLengthLimit: 4, DropLongAttributeKeys: drop}
+		tp := NewTracerProvider(WithSpanLimits(limits), WithSyncer(te), WithResource(resource.Empty()))
+
+		linkAttrs := []attribute.KeyValue{attribute.Int("link", 1), attribute.Int("links", 2)}
+		_, span := tp.Tracer("AttributeKeyLengthLimit").Start(
+			context.Background(),
+			"span",
+			trace.WithLinks(trace.Link{SpanContext: sc, Attributes: linkAttrs}),
+		)
+		span.SetAttributes(
+			attribute.Int("abc", 1),
+			attribute.Int("abcd", 2),
+			attribute.Int("abcde", 3),
+			attribute.Int("äöüßx", 4),
+		)
+		eventAttrs := []attribute.KeyValue{attribute.Int("events", 1), attribute.Int("evt", 2)}
+		span.AddEvent("event", trace.WithAttributes(eventAttrs...))
+		got, err := endSpan(te, span)
+		require.NoError(t, err)
+		require.Len(t, got.MessageEvents, 1)
+		require.Len(t, got.Links, 1)
+
+		if drop {
+			assert.Equal(t, []attribute.KeyValue{
+				attribute.Int("abc", 1),
+				attribute.Int("abcd", 2),
+			}, got.Attributes)
+			assert.Equal(t, 2, got.DroppedAttributeCount)
+			assert.Equal(t, []attribute.KeyValue{attribute.Int("evt", 2)}, got.MessageEvents[0].Attributes)
+			assert.Equal(t, 1, got.MessageEvents[0].DroppedAttributeCount)
+			assert.Equal(t, []attribute.KeyValue{attribute.Int("link", 1)}, got.Links[0].Attributes)
+			assert.Equal(t, 1, got.Links[0].DroppedAttributeCount)
+		} else {
+			// The truncated "abcde" key collides with "abcd".
+			assert.Equal(t, []attribute.KeyValue{
+				attribute.Int("abc", 1),
+				attribute.Int("abcd", 3),
+				attribute.Int("äöüß", 4),
+			}, got.Attributes)
+			assert.Equal(t, 0, got.DroppedAttributeCount)
+			assert.Equal(t, []attribute.KeyValue{
+				attribute.Int("even", 1),
+				attribute.Int("evt", 2),
+			}, got.MessageEvents[0].Attributes)
+			assert.Equal(t, []attribute.KeyValue{
+				attribute.Int("link", 1),
+				attribute.Int("link", 2),
+			}, got.Links[0].Attributes)
+		}
+
+		// The passed attributes are not modified.
+		assert.Equal(t, attribute.Key("events"), eventAttrs[0].Key)
+		assert.Equal(t, attribute.Key("links"), linkAttrs[1].Key)
+	}
+}
+
 func TestSetSpanAttributesOverLimit(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSpanLimits(SpanLimits{AttributeCountLimit: 2}), WithSyncer(te), WithResource(resource.Empty()))
@@ -494,6 +737,27 @@ func TestSetSpanAttributesOverLimit(t *testing.T) {
 	}
 }
 
+func TestSpanAttributesOverLimitAccumulateDropped(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{AttributeCountLimit: 2}), WithSyncer(te), WithResource(resource.Empty()))
+
+	_, span := tp.Tracer("SpanAttributesOverLimit").Start(
+		context.Background(),
+		"span",
+		trace.WithAttributes(kv1, kv2, kv3),
+	)
+	span.SetAttributes(attribute.Int("key4", 4))
+	span.SetAttributes(attribute.Int("key5", 5), attribute.Int("key6", 6))
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int("key5", 5),
+		attribute.Int("key6", 6),
+	}, got.Attributes)
+	assert.Equal(t, 4, got.DroppedAttributeCount)
+}
+
 func TestSetSpanAttributesWithInvalidKey(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSpanLimits(SpanLimits{}), WithSyncer(te), WithResource(resource.Empty()))
@@ -519,7 +783,7 @@ func TestSetSpanAttributesWithInvalidKey(t *testing.T) {
 			attribute.Bool("key1", false),
 		},
 		SpanKind:               trace.SpanKindInternal,
-		DroppedAttributeCount:  0,
+		DroppedAttributeCount:  1,
 		InstrumentationLibrary: instrumentation.Library{Name: "SpanToSetInvalidKeyOrValue"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
@@ -527,6 +791,44 @@ func TestSetSpanAttributesWithInvalidKey(t *testing.T) {
 	}
 }
 
+func TestSetSpanAttributesWithNonFiniteFloats(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+
+	handler.Reset()
+	ctx := context.Background()
+	_, span := tp.Tracer("NonFiniteFloats").Start(ctx, "span", trace.WithAttributes(
+		attribute.Float64("nan", math.NaN()),
+		attribute.Float64("start", 1.5),
+	))
+	span.SetAttributes(
+		attribute.Float64("inf", math.Inf(1)),
+		attribute.Float64("-inf", math.Inf(-1)),
+		attribute.Array("floats", []float64{1, math.NaN()}),
+		attribute.Array("finite", []float64{1, 2}),
+		attribute.Array("strings", []string{"a"}),
+		attribute.Bool("", true),
+	)
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Float64("start", 1.5),
+		attribute.Array("finite", []float64{1, 2}),
+		attribute.Array("strings", []string{"a"}),
+	}, got.Attributes)
+	assert.Equal(t, 5, got.DroppedAttributeCount)
+
+	require.Len(t, handler.errs, 5)
+	var keys []attribute.Key
+	for _, err := range handler.errs {
+		var attrErr *InvalidAttributeError
+		require.True(t, errors.As(err, &attrErr), "unexpected error type: %T", err)
+		keys = append(keys, attrErr.Attribute.Key)
+	}
+	assert.Equal(t, []attribute.Key{"nan", "inf", "-inf", "floats", ""}, keys)
+}
+
 func TestEvents(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -621,6 +923,30 @@ func TestEventsOverLimit(t *testing.T) {
 	}
 }
 
+func TestStartEvent(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{EventCountLimit: 2}), WithSyncer(te), WithResource(resource.Empty()))
+
+	start := time.Now().Add(-time.Minute)
+	_, span := tp.Tracer("StartEvent").Start(
+		context.Background(),
+		"span",
+		trace.WithTimestamp(start),
+		trace.WithStartEvent("dropped"),
+		trace.WithStartEvent("start", kv1),
+	)
+	span.AddEvent("event")
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	require.Len(t, got.MessageEvents, 2)
+	assert.Equal(t, "start", got.MessageEvents[0].Name)
+	assert.Equal(t, []attribute.KeyValue{kv1}, got.MessageEvents[0].Attributes)
+	assert.Equal(t, start, got.MessageEvents[0].Time)
+	assert.Equal(t, "event", got.MessageEvents[1].Name)
+	assert.Equal(t, 1, got.DroppedMessageEventCount)
+}
+
 func TestLinks(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -659,6 +985,46 @@ func TestLinks(t *testing.T) {
 	}
 }
 
+func TestInvalidLinksAreDropped(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSpanLimits(SpanLimits{LinkCountLimit: 1}),
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+	)
+
+	sc1 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{3}})
+	noSpanID := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1})})
+
+	span := startSpan(tp, "InvalidLinks", trace.WithLinks(
+		trace.Link{SpanContext: trace.SpanContext{}},
+		trace.Link{SpanContext: sc1, Attributes: []attribute.KeyValue{kv1}},
+		trace.Link{SpanContext: noSpanID},
+	))
+
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &SpanSnapshot{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		Parent: sc.WithRemote(true),
+		Name:   "span0",
+		Links: []trace.Link{
+			{SpanContext: sc1, Attributes: []attribute.KeyValue{kv1}},
+		},
+		SpanKind:               trace.SpanKindInternal,
+		InstrumentationLibrary: instrumentation.Library{Name: "InvalidLinks"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("Invalid links: -got +want %s", diff)
+	}
+}
+
 func TestLinksOverLimit(t *testing.T) {
 	te := NewTestExporter()
 
@@ -722,6 +1088,34 @@ func TestSetSpanName(t *testing.T) {
 	}
 }
 
+func TestRenameSpan(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	ctx := context.Background()
+
+	want := "SpanName-2"
+	ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+	_, span := tp.Tracer("SetSpanName").Start(ctx, "SpanName-1")
+	span.SetName(want)
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != want {
+		t.Errorf("span.Name: got %q; want %q", got.Name, want)
+	}
+
+	// Renaming an ended span must be ignored.
+	span.SetName("SpanName-3")
+	if name := span.(ReadOnlySpan).Name(); name != want {
+		t.Errorf("span.Name after End: got %q; want %q", name, want)
+	}
+	if name := te.Spans()[0].Name; name != want {
+		t.Errorf("exported span.Name after End: got %q; want %q", name, want)
+	}
+}
+
 func TestSetSpanStatus(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -1278,6 +1672,41 @@ func TestWithResource(t *testing.T) {
 	}
 }
 
+func TestWithResourceConflictWarning(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envVar: "service.name=detected,rk1=same,rk2=env",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	explicit := resource.NewWithAttributes(
+		semconv.ServiceNameKey.String("explicit"),
+		attribute.String("rk1", "same"),
+		attribute.String("rk3", "new"),
+	)
+	want := resource.NewWithAttributes(
+		semconv.ServiceNameKey.String("explicit"),
+		attribute.String("rk1", "same"),
+		attribute.String("rk2", "env"),
+		attribute.String("rk3", "new"),
+	)
+
+	handler.Reset()
+	tp := NewTracerProvider(WithResource(explicit))
+	assert.Equal(t, want, tp.resource)
+	assert.Empty(t, handler.errs, "conflicts reported by default")
+
+	tp = NewTracerProvider(WithResourceConflictWarning(), WithResource(explicit))
+	assert.Equal(t, want, tp.resource)
+	require.Len(t, handler.errs, 1)
+	assert.Contains(t, handler.errs[0].Error(), "service.name=detected")
+	assert.Contains(t, handler.errs[0].Error(), "service.name=explicit")
+
+	handler.Reset()
+	NewTracerProvider(WithResourceConflictWarning())
+	assert.Empty(t, handler.errs, "conflicts reported without explicit resource")
+}
+
 func TestWithInstrumentationVersion(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -1450,6 +1879,48 @@ func TestReadWriteSpan(t *testing.T) {
 	// duplication.
 }
 
+func TestAttributeCapacity(t *testing.T) {
+	attrs := []attribute.KeyValue{
+		attribute.Bool("key1", true),
+		attribute.String("key2", "value2"),
+		attribute.Int("key3", 3),
+	}
+
+	var got []*SpanSnapshot
+	for _, n := range []int{0, 2, 10} {
+		te := NewTestExporter()
+		tp := NewTracerProvider(WithSyncer(te), WithSpanLimits(SpanLimits{AttributeCountLimit: 2}))
+		span := startSpan(tp, "AttributeCapacity", trace.WithAttributeCapacity(n))
+		span.SetAttributes(attrs...)
+		ss, err := endSpan(te, span)
+		require.NoError(t, err)
+		got = append(got, ss)
+	}
+
+	for _, ss := range got {
+		assert.Equal(t, attrs[1:], ss.Attributes)
+		assert.Equal(t, 1, ss.DroppedAttributeCount)
+	}
+}
+
+func TestNonRecordingSpanReadOnly(t *testing.T) {
+	tp := NewTracerProvider(WithSampler(NeverSample()))
+	_, s := tp.Tracer("NonRecording").Start(context.Background(), "span")
+	s.SetAttributes(attribute.String("key", "value"))
+	s.AddEvent("event")
+
+	ro, ok := s.(ReadOnlySpan)
+	require.True(t, ok)
+	assert.Empty(t, ro.Attributes())
+	assert.Empty(t, ro.Events())
+	assert.Empty(t, ro.Links())
+
+	ss := ro.Snapshot()
+	assert.Nil(t, ss.Attributes)
+	assert.Nil(t, ss.MessageEvents)
+	assert.Nil(t, ss.Links)
+}
+
 func TestAddEventsWithMoreAttributesThanLimit(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(
@@ -1710,6 +2181,92 @@ func TestSamplerTraceState(t *testing.T) {
 
 }
 
+type entriesSampler struct {
+	state   trace.TraceState
+	entries []attribute.KeyValue
+}
+
+func (s entriesSampler) ShouldSample(SamplingParameters) SamplingResult {
+	return SamplingResult{
+		Decision:          RecordAndSample,
+		Tracestate:        s.state,
+		TracestateEntries: s.entries,
+	}
+}
+
+func (s entriesSampler) Description() string {
+	return "entriesSampler"
+}
+
+// Check that SamplingResult.TracestateEntries are merged into the TraceState
+// of a new span.
+func TestSamplerTraceStateEntries(t *testing.T) {
+	mustTS := func(t trace.TraceState, err error) trace.TraceState { return t }
+	ot := attribute.String("ot", "r:6;p:4")
+	otOld := attribute.String("ot", "r:1;p:1")
+
+	tests := []struct {
+		name    string
+		sampler Sampler
+		input   trace.TraceState
+		want    trace.TraceState
+	}{
+		{
+			name:    "mergedIntoParent",
+			sampler: entriesSampler{entries: []attribute.KeyValue{ot}},
+			input:   mustTS(trace.TraceStateFromKeyValues(kv1, kv2)),
+			want:    mustTS(trace.TraceStateFromKeyValues(ot, kv1, kv2)),
+		},
+		{
+			name:    "replacesParentEntry",
+			sampler: entriesSampler{entries: []attribute.KeyValue{ot}},
+			input:   mustTS(trace.TraceStateFromKeyValues(kv1, otOld)),
+			want:    mustTS(trace.TraceStateFromKeyValues(ot, kv1)),
+		},
+		{
+			name: "mergedIntoTracestate",
+			sampler: entriesSampler{
+				state:   mustTS(trace.TraceStateFromKeyValues(kv3)),
+				entries: []attribute.KeyValue{ot, kv2},
+			},
+			input: mustTS(trace.TraceStateFromKeyValues(kv1)),
+			want:  mustTS(trace.TraceStateFromKeyValues(ot, kv2, kv3)),
+		},
+		{
+			name:    "invalidEntryIgnored",
+			sampler: entriesSampler{entries: []attribute.KeyValue{attribute.String("", "v"), ot}},
+			input:   mustTS(trace.TraceStateFromKeyValues(kv1)),
+			want:    mustTS(trace.TraceStateFromKeyValues(ot, kv1)),
+		},
+	}
+
+	for _, ts := range tests {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			te := NewTestExporter()
+			tp := NewTracerProvider(WithSampler(ts.sampler), WithSyncer(te), WithResource(resource.Empty()))
+			tr := tp.Tracer("TraceStateEntries")
+
+			sc := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    tid,
+				SpanID:     sid,
+				TraceFlags: trace.FlagsSampled,
+				TraceState: ts.input,
+			})
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+			_, span := tr.Start(ctx, "span0")
+			require.Equal(t, ts.want, span.SpanContext().TraceState())
+			span.End()
+
+			got := te.Spans()
+			require.Len(t, got, 1)
+			if diff := cmpDiff(got[0].SpanContext.TraceState(), ts.want); diff != "" {
+				t.Errorf("TraceState not propagated: -got +want %s", diff)
+			}
+		})
+	}
+}
+
 type testIDGenerator struct {
 	traceID int
 	spanID  int
@@ -1757,3 +2314,213 @@ func TestWithIDGenerator(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestDefaultIDGeneratorConcurrentSafe(t *testing.T) {
+	const (
+		goroutines = 10
+		numIDs     = 100
+	)
+
+	gen := defaultIDGenerator()
+	ctx := context.Background()
+	ids := make(chan trace.SpanID, goroutines*numIDs*2)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numIDs; j++ {
+				tid, sid := gen.NewIDs(ctx)
+				assert.True(t, tid.IsValid())
+				ids <- sid
+				ids <- gen.NewSpanID(ctx, tid)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[trace.SpanID]struct{})
+	for id := range ids {
+		assert.True(t, id.IsValid())
+		seen[id] = struct{}{}
+	}
+	assert.Len(t, seen, goroutines*numIDs*2)
+}
+
+func TestSpanSnapshotClone(t *testing.T) {
+	assert.Nil(t, (*SpanSnapshot)(nil).Clone())
+
+	ss := &SpanSnapshot{
+		SpanContext:   sc,
+		Name:          "span",
+		Attributes:    []attribute.KeyValue{kv1},
+		MessageEvents: []Event{{Name: "event", Attributes: []attribute.KeyValue{kv2}}},
+		Links:         []trace.Link{{SpanContext: sc, Attributes: []attribute.KeyValue{kv3}}},
+		Resource:      resource.NewWithAttributes(kv1),
+	}
+	c := ss.Clone()
+	if diff := cmpDiff(c, ss); diff != "" {
+		t.Fatalf("clone differs: %s", diff)
+	}
+	assert.Same(t, ss.Resource, c.Resource)
+
+	c.Attributes[0] = kv2
+	c.Attributes = append(c.Attributes, kv3)
+	c.MessageEvents[0].Name = "changed"
+	c.MessageEvents[0].Attributes[0] = kv3
+	c.MessageEvents[0].Attributes = append(c.MessageEvents[0].Attributes, kv1)
+	assert.Equal(t, []attribute.KeyValue{kv3}, c.Links[0].Attributes, "event attributes overflow into link attributes")
+	c.Links[0].Attributes[0] = kv1
+
+	assert.Equal(t, []attribute.KeyValue{kv1}, ss.Attributes)
+	assert.Equal(t, []Event{{Name: "event", Attributes: []attribute.KeyValue{kv2}}}, ss.MessageEvents)
+	assert.Equal(t, []attribute.KeyValue{kv3}, ss.Links[0].Attributes)
+}
+
+func TestWithSpanSampled(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithSampler(ParentBased(NeverSample())))
+	tr := tp.Tracer("WithSpanSampled")
+
+	ctx, forced := tr.Start(context.Background(), "forced", trace.WithSpanSampled(true))
+	assert.True(t, forced.SpanContext().IsSampled())
+	assert.True(t, forced.IsRecording())
+	// The children of the forced span follow its sampled flag.
+	_, child := tr.Start(ctx, "child")
+	assert.True(t, child.SpanContext().IsSampled())
+	_, dropped := tr.Start(ctx, "dropped", trace.WithSpanSampled(false))
+	assert.False(t, dropped.SpanContext().IsSampled())
+	assert.False(t, dropped.IsRecording())
+	dropped.End()
+	child.End()
+	forced.End()
+
+	_, other := tr.Start(context.Background(), "other")
+	assert.False(t, other.SpanContext().IsSampled())
+	other.End()
+
+	spans := te.Spans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "forced", spans[1].Name)
+}
+
+func TestMaxSpanDepth(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithMaxSpanDepth(2), WithSampler(AlwaysSample()))
+	tr := tp.Tracer("MaxSpanDepth")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	ctx, child := tr.Start(ctx, "child")
+	gctx, grandchild := tr.Start(ctx, "grandchild")
+	_, deeper := tr.Start(gctx, "deeper")
+	_, newRoot := tr.Start(gctx, "new root", trace.WithNewRoot())
+	assert.True(t, root.IsRecording())
+	assert.True(t, child.IsRecording())
+	assert.False(t, grandchild.IsRecording())
+	assert.False(t, grandchild.SpanContext().IsSampled())
+	assert.False(t, deeper.IsRecording())
+	assert.True(t, newRoot.IsRecording())
+	for _, s := range []trace.Span{deeper, grandchild, child, root, newRoot} {
+		s.End()
+	}
+
+	// The children of a remote span start a new local tree.
+	rctx := trace.ContextWithRemoteSpanContext(gctx, child.SpanContext())
+	_, remoteChild := tr.Start(rctx, "remote child")
+	assert.True(t, remoteChild.IsRecording())
+	remoteChild.End()
+
+	var names []string
+	for _, s := range te.Spans() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"child", "root", "new root", "remote child"}, names)
+}
+
+func TestUnlimitedSpanDepth(t *testing.T) {
+	tp := NewTracerProvider(WithSampler(AlwaysSample()))
+	tr := tp.Tracer("UnlimitedSpanDepth")
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		var s trace.Span
+		ctx, s = tr.Start(ctx, "span")
+		require.True(t, s.IsRecording())
+	}
+	assert.Nil(t, ctx.Value(spanDepthKey{}))
+}
+
+func TestDefaultSpanAttributes(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithResource(resource.Empty()),
+		WithDefaultSpanAttributes(
+			attribute.String("service.version", "1.0.0"),
+			attribute.String("deployment", "blue"),
+		),
+		WithDefaultSpanAttributes(attribute.Bool("default", true)),
+	)
+	tr := tp.Tracer("DefaultSpanAttributes")
+
+	_, span := tr.Start(context.Background(), "span", trace.WithAttributes(attribute.String("deployment", "green")))
+	span.SetAttributes(attribute.Bool("default", false))
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("service.version", "1.0.0"),
+		attribute.String("deployment", "green"),
+		attribute.Bool("default", false),
+	}, got.Attributes)
+}
+
+func TestForceExport(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithSampler(NeverSample()))
+	tr := tp.Tracer("ForceExport")
+
+	_, unsampled := tr.Start(context.Background(), "unsampled")
+	assert.False(t, unsampled.IsRecording())
+	unsampled.End()
+
+	ctx := trace.ContextWithForceExport(context.Background())
+	ctx, parent := tr.Start(ctx, "parent")
+	assert.True(t, parent.SpanContext().IsSampled())
+	_, child := tr.Start(ctx, "child")
+	assert.True(t, child.SpanContext().IsSampled())
+	_, explicit := tr.Start(ctx, "explicit", trace.WithSpanSampled(false))
+	assert.False(t, explicit.IsRecording())
+	for _, s := range []trace.Span{explicit, child, parent} {
+		s.End()
+	}
+
+	var names []string
+	for _, s := range te.Spans() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"child", "parent"}, names)
+}
+
+func TestDebugTraceFlags(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithSampler(NeverSample()))
+	tr := tp.Tracer("DebugTraceFlags")
+
+	parent := sc.WithTraceFlags(trace.FlagsDebug)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+	ctx, span := tr.Start(ctx, "debug")
+	_, child := tr.Start(ctx, "child")
+	for _, s := range []trace.Span{span, child} {
+		assert.True(t, s.IsRecording())
+		assert.True(t, s.SpanContext().IsSampled())
+		assert.True(t, s.SpanContext().IsDebug())
+	}
+	child.End()
+	span.End()
+	assert.Len(t, te.Spans(), 2)
+
+	_, span = tr.Start(trace.ContextWithRemoteSpanContext(context.Background(), sc), "not debug")
+	assert.False(t, span.IsRecording())
+}
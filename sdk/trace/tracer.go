@@ -17,6 +17,7 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 import (
 	"context"
 	rt "runtime/trace"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -26,10 +27,21 @@ import (
 type tracer struct {
 	provider               *TracerProvider
 	instrumentationLibrary instrumentation.Library
+
+	// sampler overrides the Sampler of the provider if not nil.
+	sampler Sampler
 }
 
 var _ trace.Tracer = &tracer{}
 
+// samplerOf returns the Sampler used for the spans of tr.
+func (tr *tracer) samplerOf() Sampler {
+	if tr.sampler != nil {
+		return tr.sampler
+	}
+	return tr.provider.sampler
+}
+
 // Start starts a Span and returns it along with a context containing it.
 //
 // The Span is created with the provided name and as a child of any existing
@@ -38,6 +50,22 @@ var _ trace.Tracer = &tracer{}
 // passed will be used as the start time of the Span's life-cycle.
 func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanOption) (context.Context, trace.Span) {
 	config := trace.NewSpanConfig(options...)
+	if config.Sampled == nil && trace.IsForceExport(ctx) {
+		forced := true
+		config.Sampled = &forced
+	}
+
+	var depth int
+	if max := tr.provider.maxSpanDepth; max > 0 {
+		if !config.NewRoot {
+			depth = spanDepthFromContext(ctx)
+		}
+		depth++
+		if depth > max {
+			dropped := false
+			config.Sampled = &dropped
+		}
+	}
 
 	// For local spans created by this SDK, track child span count.
 	if p := trace.SpanFromContext(ctx); p != nil {
@@ -50,15 +78,29 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanO
 	for _, l := range config.Links {
 		span.addLink(l)
 	}
+	if config.SourceLocation && span.IsRecording() {
+		span.SetAttributes(sourceLocation()...)
+	}
 	span.SetAttributes(config.Attributes...)
+	if span.IsRecording() {
+		for _, e := range config.StartEvents {
+			span.addEvent(e.Name, trace.WithTimestamp(span.startTime), trace.WithAttributes(e.Attributes...))
+		}
+	}
 
 	span.tracer = tr
 
+	atomic.AddUint64(&tr.provider.spanCounts.started, 1)
+	if span.SpanContext().IsSampled() {
+		atomic.AddUint64(&tr.provider.spanCounts.sampled, 1)
+	}
+
 	if span.IsRecording() {
 		sps, _ := tr.provider.spanProcessors.Load().(spanProcessorStates)
 		for _, sp := range sps {
 			sp.sp.OnStart(ctx, span)
 		}
+		observe(tr.provider.startObservers, span)
 	}
 
 	ctx, span.executionTracerTaskEnd = func(ctx context.Context) (context.Context, func()) {
@@ -71,5 +113,26 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanO
 		return nctx, task.End
 	}(ctx)
 
+	if depth > 0 {
+		ctx = contextWithSpanDepth(ctx, depth)
+	}
 	return trace.ContextWithSpan(ctx, span), span
 }
+
+// spanDepthKey is the context key of the depth of the Span of a context.
+type spanDepthKey struct{}
+
+func contextWithSpanDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, spanDepthKey{}, depth)
+}
+
+// spanDepthFromContext returns the depth of the Span of ctx, 0 if ctx has
+// no Span or its Span was not started with a depth limit. The depth of a
+// remote Span is 0, its local children are roots of the local span tree.
+func spanDepthFromContext(ctx context.Context) int {
+	if sc := trace.SpanContextFromContext(ctx); !sc.IsValid() || sc.IsRemote() {
+		return 0
+	}
+	depth, _ := ctx.Value(spanDepthKey{}).(int)
+	return depth
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest // import "go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	apitrace "go.opentelemetry.io/otel/trace"
+)
+
+var _ trace.IDGenerator = (*SeededIDGenerator)(nil)
+
+// NewSeededIDGenerator returns a new SeededIDGenerator generating the
+// sequence of IDs determined by seed.
+func NewSeededIDGenerator(seed int64) *SeededIDGenerator {
+	return &SeededIDGenerator{randSource: rand.New(rand.NewSource(seed))}
+}
+
+// SeededIDGenerator is an IDGenerator generating reproducible trace and span
+// IDs from a math/rand source. Two SeededIDGenerators created with the same
+// seed generate the same IDs when called in the same order.
+//
+// The generated IDs are predictable, SeededIDGenerator is not
+// cryptographically secure and must only be used for testing.
+type SeededIDGenerator struct {
+	mu         sync.Mutex
+	randSource *rand.Rand
+}
+
+// NewIDs returns the next non-zero trace ID and non-zero span ID of the
+// sequence.
+func (gen *SeededIDGenerator) NewIDs(ctx context.Context) (apitrace.TraceID, apitrace.SpanID) {
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+	tid := apitrace.TraceID{}
+	for !tid.IsValid() {
+		gen.randSource.Read(tid[:])
+	}
+	return tid, gen.newSpanID()
+}
+
+// NewSpanID returns the next non-zero span ID of the sequence.
+func (gen *SeededIDGenerator) NewSpanID(ctx context.Context, traceID apitrace.TraceID) apitrace.SpanID {
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+	return gen.newSpanID()
+}
+
+func (gen *SeededIDGenerator) newSpanID() apitrace.SpanID {
+	sid := apitrace.SpanID{}
+	for !sid.IsValid() {
+		gen.randSource.Read(sid[:])
+	}
+	return sid
+}
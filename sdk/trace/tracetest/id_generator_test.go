@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSeededIDGeneratorIsReproducible(t *testing.T) {
+	ctx := context.Background()
+	a, b := NewSeededIDGenerator(42), NewSeededIDGenerator(42)
+	for i := 0; i < 10; i++ {
+		aTID, aSID := a.NewIDs(ctx)
+		bTID, bSID := b.NewIDs(ctx)
+		assert.True(t, aTID.IsValid())
+		assert.True(t, aSID.IsValid())
+		assert.Equal(t, aTID, bTID)
+		assert.Equal(t, aSID, bSID)
+		assert.Equal(t, a.NewSpanID(ctx, aTID), b.NewSpanID(ctx, bTID))
+	}
+
+	other, _ := NewSeededIDGenerator(7).NewIDs(ctx)
+	first, _ := NewSeededIDGenerator(42).NewIDs(ctx)
+	assert.NotEqual(t, first, other)
+}
+
+func TestSeededIDGeneratorWithTracerProvider(t *testing.T) {
+	run := func() []*trace.SpanSnapshot {
+		imsb := NewInMemoryExporter()
+		tp := trace.NewTracerProvider(
+			trace.WithSyncer(imsb),
+			trace.WithIDGenerator(NewSeededIDGenerator(1)),
+		)
+		ctx, parent := tp.Tracer("SeededIDGenerator").Start(context.Background(), "parent")
+		_, child := tp.Tracer("SeededIDGenerator").Start(ctx, "child")
+		child.End()
+		parent.End()
+		return imsb.GetSpans()
+	}
+
+	got, want := run(), run()
+	require.Len(t, got, 2)
+	for i := range want {
+		assert.Equal(t, want[i].SpanContext.TraceID(), got[i].SpanContext.TraceID())
+		assert.Equal(t, want[i].SpanContext.SpanID(), got[i].SpanContext.SpanID())
+	}
+}
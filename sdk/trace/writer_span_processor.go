@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// writerSpanProcessor is a SpanProcessor that writes every ended span to an
+// io.Writer.
+type writerSpanProcessor struct {
+	mu      sync.Mutex
+	w       io.Writer
+	enc     *json.Encoder
+	stopped bool
+}
+
+var _ SpanProcessor = (*writerSpanProcessor)(nil)
+
+// NewWriterProcessor returns a SpanProcessor that synchronously writes every
+// ended and sampled span as a JSON object, followed by a newline, to w. It is
+// meant for debugging and examples where setting up an exporter is not worth
+// it. A SimpleSpanProcessor with an exporter is recommended otherwise.
+//
+// If w has a Flush method, like a *bufio.Writer, it is called when the
+// processor is flushed or shut down.
+func NewWriterProcessor(w io.Writer) SpanProcessor {
+	return &writerSpanProcessor{w: w, enc: json.NewEncoder(w)}
+}
+
+// OnStart does nothing.
+func (wsp *writerSpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnd writes s to the writer.
+func (wsp *writerSpanProcessor) OnEnd(s ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	ss := s.Snapshot()
+
+	wsp.mu.Lock()
+	defer wsp.mu.Unlock()
+	if wsp.stopped {
+		return
+	}
+	if err := wsp.enc.Encode(ss); err != nil {
+		otel.Handle(err)
+	}
+}
+
+// Shutdown flushes the writer and stops the processor from writing any
+// further spans.
+func (wsp *writerSpanProcessor) Shutdown(ctx context.Context) error {
+	wsp.mu.Lock()
+	defer wsp.mu.Unlock()
+	if wsp.stopped {
+		return nil
+	}
+	wsp.stopped = true
+	if err := wsp.flush(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// ForceFlush flushes the writer.
+func (wsp *writerSpanProcessor) ForceFlush(context.Context) error {
+	wsp.mu.Lock()
+	defer wsp.mu.Unlock()
+	return wsp.flush()
+}
+
+func (wsp *writerSpanProcessor) flush() error {
+	if f, ok := wsp.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestWriterProcessor(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	wsp := sdktrace.NewWriterProcessor(w)
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(wsp)).Tracer("Writer")
+
+	ctx := context.Background()
+	_, span := tr.Start(ctx, "span 0")
+	span.End()
+	_, span = tr.Start(ctx, "span 1")
+	span.End()
+	assert.Empty(t, buf.String(), "written before flush")
+
+	require.NoError(t, wsp.Shutdown(ctx))
+	dec := json.NewDecoder(&buf)
+	for _, name := range []string{"span 0", "span 1"} {
+		var got struct{ Name string }
+		require.NoError(t, dec.Decode(&got))
+		assert.Equal(t, name, got.Name)
+	}
+	assert.False(t, dec.More())
+
+	_, span = tr.Start(ctx, "after shutdown")
+	span.End()
+	require.NoError(t, w.Flush())
+	assert.Empty(t, buf.String())
+}
+
+func TestWriterProcessorIgnoresUnsampledSpans(t *testing.T) {
+	var buf bytes.Buffer
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewWriterProcessor(&buf)),
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+	)
+	_, span := tp.Tracer("Writer").Start(context.Background(), "not sampled")
+	span.End()
+	assert.Empty(t, buf.String())
+}
@@ -60,6 +60,25 @@ type SpanConfig struct {
 	NewRoot bool
 	// SpanKind is the role a Span has in a trace.
 	SpanKind SpanKind
+	// StartEvents are the events recorded at the start time of a Span.
+	StartEvents []StartEvent
+	// AttributeCapacity is the number of attributes a Span is expected to
+	// hold. It is a hint used to size the storage of the Span attributes.
+	AttributeCapacity int
+	// Sampled, if not nil, overrides the sampling decision of the Sampler
+	// for the Span: the Span is sampled if it is true, and dropped otherwise.
+	Sampled *bool
+	// SourceLocation identifies that the source location of the call
+	// starting the Span is added to its attributes.
+	SourceLocation bool
+}
+
+// StartEvent is an event recorded when a Span is started.
+type StartEvent struct {
+	// Name is the name of the event.
+	Name string
+	// Attributes describe the event.
+	Attributes []attribute.KeyValue
 }
 
 // NewSpanConfig applies all the options to a returned SpanConfig.
@@ -185,6 +204,76 @@ func WithSpanKind(kind SpanKind) SpanOption {
 	return spanKindSpanOption(kind)
 }
 
+type startEventSpanOption StartEvent
+
+func (o startEventSpanOption) ApplySpan(c *SpanConfig) {
+	c.StartEvents = append(c.StartEvents, StartEvent(o))
+}
+func (startEventSpanOption) private() {}
+
+// WithStartEvent adds an event with the given name and attributes to a Span
+// when it is started. The event has the start time of the Span. This avoids
+// adding the event manually right after the Span is started.
+//
+// If multiple of these options are passed, the events are added in the order
+// they are passed.
+func WithStartEvent(name string, attributes ...attribute.KeyValue) SpanOption {
+	return startEventSpanOption{Name: name, Attributes: attributes}
+}
+
+type attributeCapacitySpanOption int
+
+func (o attributeCapacitySpanOption) ApplySpan(c *SpanConfig) { c.AttributeCapacity = int(o) }
+func (attributeCapacitySpanOption) private()                  {}
+
+// WithAttributeCapacity hints that the Span is expected to hold n attributes
+// so their storage can be sized at the Span start. The hint does not limit
+// the number of attributes the Span holds. A negative n is ignored.
+func WithAttributeCapacity(n int) SpanOption {
+	if n < 0 {
+		n = 0
+	}
+	return attributeCapacitySpanOption(n)
+}
+
+type sampledSpanOption bool
+
+func (o sampledSpanOption) ApplySpan(c *SpanConfig) {
+	sampled := bool(o)
+	c.Sampled = &sampled
+}
+func (sampledSpanOption) private() {}
+
+// WithSpanSampled overrides the sampling decision of the configured Sampler
+// for the Span: the Span is recorded and sampled if sampled is true, and
+// dropped otherwise. The sampled flag of the Span context, which is
+// propagated to its children, follows. This is an escape hatch to keep the
+// spans of critical operations, e.g. payments.
+//
+// The spans whose sampling decision is overridden are not accounted for by
+// the Sampler: the statistics derived from the ratio of sampled spans, like
+// upscaled span counts, do not hold anymore for them.
+func WithSpanSampled(sampled bool) SpanOption {
+	return sampledSpanOption(sampled)
+}
+
+type sourceLocationSpanOption bool
+
+func (o sourceLocationSpanOption) ApplySpan(c *SpanConfig) {
+	c.SourceLocation = bool(o)
+}
+func (sourceLocationSpanOption) private() {}
+
+// WithSourceLocation adds the source location of the call starting the Span,
+// i.e. its function, file path and line number, to the Span as the
+// code.namespace, code.function, code.filepath and code.lineno semantic
+// convention attributes. This helps pinpoint where a Span originated when
+// debugging. The location is only resolved, with its overhead, when this
+// option is used.
+func WithSourceLocation() SpanOption {
+	return sourceLocationSpanOption(true)
+}
+
 // InstrumentationOption is an interface for applying instrumentation specific
 // options.
 type InstrumentationOption interface {
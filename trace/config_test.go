@@ -31,6 +31,8 @@ func TestNewSpanConfig(t *testing.T) {
 	timestamp0 := time.Unix(0, 0)
 	timestamp1 := time.Unix(0, 0)
 
+	sampled, notSampled := true, false
+
 	link1 := Link{
 		SpanContext: SpanContext{traceID: TraceID([16]byte{1, 1}), spanID: SpanID{3}},
 		Attributes:  []attribute.KeyValue{k1v1},
@@ -151,6 +153,59 @@ func TestNewSpanConfig(t *testing.T) {
 				SpanKind: SpanKindConsumer,
 			},
 		},
+		{
+			[]SpanOption{
+				// Multiple calls should append in order.
+				WithStartEvent("start", k1v1),
+				WithStartEvent("created"),
+			},
+			&SpanConfig{
+				StartEvents: []StartEvent{
+					{Name: "start", Attributes: []attribute.KeyValue{k1v1}},
+					{Name: "created"},
+				},
+			},
+		},
+		{
+			[]SpanOption{
+				WithAttributeCapacity(10),
+			},
+			&SpanConfig{
+				AttributeCapacity: 10,
+			},
+		},
+		{
+			[]SpanOption{
+				// Negative capacities are ignored.
+				WithAttributeCapacity(-1),
+			},
+			&SpanConfig{},
+		},
+		{
+			[]SpanOption{
+				WithSpanSampled(true),
+			},
+			&SpanConfig{
+				Sampled: &sampled,
+			},
+		},
+		{
+			[]SpanOption{
+				WithSpanSampled(true),
+				WithSpanSampled(false),
+			},
+			&SpanConfig{
+				Sampled: &notSampled,
+			},
+		},
+		{
+			[]SpanOption{
+				WithSourceLocation(),
+			},
+			&SpanConfig{
+				SourceLocation: true,
+			},
+		},
 		{
 			// Everything should work together.
 			[]SpanOption{
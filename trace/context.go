@@ -14,11 +14,26 @@
 
 package trace // import "go.opentelemetry.io/otel/trace"
 
-import "context"
+import (
+	"context"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+)
 
 type traceContextKeyType int
 
-const currentSpanKey traceContextKeyType = iota
+const (
+	currentSpanKey traceContextKeyType = iota
+	forceExportKey
+)
+
+const (
+	// traceIDFieldKey and spanIDFieldKey are the keys of the fields
+	// returned by SpanContextFields.
+	traceIDFieldKey = attribute.Key("trace_id")
+	spanIDFieldKey  = attribute.Key("span_id")
+)
 
 // ContextWithSpan returns a copy of parent with span set as the current Span.
 func ContextWithSpan(parent context.Context, span Span) context.Context {
@@ -41,6 +56,27 @@ func ContextWithRemoteSpanContext(parent context.Context, rsc SpanContext) conte
 	return ContextWithSpanContext(parent, rsc.WithRemote(true))
 }
 
+// ContextWithForceExport returns a copy of parent marked so the Spans started
+// with it, or with any context derived from it, e.g. the contexts of their
+// children, are always recorded and exported regardless of the sampling
+// decision of the SDK. This is intended for the targeted debugging of
+// specific requests in production. An explicit WithSpanSampled StartOption
+// takes precedence over this mark.
+//
+// The mark is local to the process, it is not propagated to remote services.
+func ContextWithForceExport(parent context.Context) context.Context {
+	return context.WithValue(parent, forceExportKey, true)
+}
+
+// IsForceExport returns whether ctx was marked by ContextWithForceExport.
+func IsForceExport(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	force, _ := ctx.Value(forceExportKey).(bool)
+	return force
+}
+
 // SpanFromContext returns the current Span from ctx.
 //
 // If no Span is currently set in ctx an implementation of a Span that
@@ -59,3 +95,28 @@ func SpanFromContext(ctx context.Context) Span {
 func SpanContextFromContext(ctx context.Context) SpanContext {
 	return SpanFromContext(ctx).SpanContext()
 }
+
+// SpanContextFields returns the trace_id and span_id fields, holding the hex
+// encoded TraceID and SpanID, of the current Span in ctx. These can be added
+// to log records to correlate them with the Span. If there is no recording
+// Span in ctx, nil is returned.
+func SpanContextFields(ctx context.Context) []attribute.KeyValue {
+	span := SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return nil
+	}
+	// Encode both IDs into a single string to save an allocation.
+	tid, sid := sc.TraceID(), sc.SpanID()
+	var buf [2 * (len(tid) + len(sid))]byte
+	hex.Encode(buf[:], tid[:])
+	hex.Encode(buf[2*len(tid):], sid[:])
+	ids := string(buf[:])
+	return []attribute.KeyValue{
+		traceIDFieldKey.String(ids[:2*len(tid)]),
+		spanIDFieldKey.String(ids[2*len(tid):]),
+	}
+}
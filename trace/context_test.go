@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type testSpan struct {
@@ -91,3 +93,43 @@ func TestSpanFromContext(t *testing.T) {
 		})
 	}
 }
+
+type recordingSpan struct {
+	testSpan
+}
+
+func (recordingSpan) IsRecording() bool { return true }
+
+func TestSpanContextFields(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, SpanContextFields(ctx))
+	assert.Nil(t, SpanContextFields(ContextWithSpan(ctx, localSpan)))
+	assert.Nil(t, SpanContextFields(ContextWithRemoteSpanContext(ctx, remoteSpan.SpanContext())))
+	assert.Nil(t, SpanContextFields(ContextWithSpan(ctx, recordingSpan{})))
+
+	got := SpanContextFields(ContextWithSpan(ctx, recordingSpan{testSpan{ID: 1}}))
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("trace_id", "01000000000000000000000000000000"),
+		attribute.String("span_id", "0100000000000000"),
+	}, got)
+}
+
+func BenchmarkSpanContextFields(b *testing.B) {
+	ctx := ContextWithSpan(context.Background(), recordingSpan{testSpan{ID: 1}})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = SpanContextFields(ctx)
+	}
+}
+
+func TestForceExport(t *testing.T) {
+	assert.False(t, IsForceExport(nil))
+	assert.False(t, IsForceExport(context.Background()))
+
+	ctx := ContextWithForceExport(context.Background())
+	assert.True(t, IsForceExport(ctx))
+	// The mark is inherited by the derived contexts.
+	assert.True(t, IsForceExport(ContextWithSpan(ctx, localSpan)))
+}
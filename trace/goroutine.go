@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/trace"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// detachedContext is a context holding the values of its parent without
+// being canceled with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// GoWithContext calls fn in a new goroutine with a context holding the
+// values of ctx, including its current Span and baggage, so the Spans
+// started by fn are children of the Span of ctx instead of the roots of new
+// traces. This prevents losing the Span context when spawning goroutines.
+//
+// The context passed to fn is never canceled and has no deadline: the
+// goroutine commonly outlives the operation spawning it, e.g. the handling
+// of a request. If fn needs to be stopped, pass it a cancellation of your
+// own, e.g. a channel, or derive a cancelable context in fn.
+func GoWithContext(ctx context.Context, fn func(context.Context)) {
+	go fn(detachedContext{parent: ctx})
+}
+
+// GoWithLinkedSpan starts a Span named name with tracer, as a new root Span
+// linked to the current Span of ctx, and calls fn in a new goroutine with a
+// context holding that Span, as GoWithContext does. The linked Span is
+// ended once fn returns. This models fan-out work whose lifetime is not
+// bounded by the spawning Span, while keeping them related.
+//
+// If fn panics, the Span status is set to Error and the Span is ended
+// before the panic is propagated.
+func GoWithLinkedSpan(ctx context.Context, tracer Tracer, name string, fn func(context.Context), opts ...SpanOption) {
+	// Copy opts so the options of the caller are not overwritten.
+	opts = append(append(make([]SpanOption, 0, len(opts)+2), opts...), WithNewRoot())
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		opts = append(opts, WithLinks(Link{SpanContext: sc}))
+	}
+	sctx, span := tracer.Start(detachedContext{parent: ctx}, name, opts...)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				span.SetStatus(codes.Error, fmt.Sprint(r))
+				span.End()
+				panic(r)
+			}
+		}()
+		fn(sctx)
+		span.End()
+	}()
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(ContextWithSpan(context.Background(), localSpan))
+	cancel()
+
+	done := make(chan context.Context)
+	GoWithContext(ctx, func(ctx context.Context) { done <- ctx })
+	got := <-done
+
+	assert.Equal(t, localSpan, SpanFromContext(got))
+	assert.NoError(t, got.Err(), "canceled with the spawning context")
+	assert.Nil(t, got.Done())
+	_, ok := got.Deadline()
+	assert.False(t, ok)
+}
+
+// endSpan closes ended when it is ended.
+type endSpan struct {
+	noopSpan
+
+	ended chan struct{}
+}
+
+func (s *endSpan) End(...SpanOption) { close(s.ended) }
+
+// linkTracer records the configuration of the Span it starts.
+type linkTracer struct {
+	config *SpanConfig
+	span   *endSpan
+}
+
+func (t *linkTracer) Start(ctx context.Context, _ string, opts ...SpanOption) (context.Context, Span) {
+	t.config = NewSpanConfig(opts...)
+	t.span = &endSpan{ended: make(chan struct{})}
+	return ContextWithSpan(ctx, t.span), t.span
+}
+
+func TestGoWithLinkedSpan(t *testing.T) {
+	tracer := &linkTracer{}
+	ctx := ContextWithSpan(context.Background(), localSpan)
+
+	done := make(chan Span)
+	GoWithLinkedSpan(ctx, tracer, "fan-out", func(ctx context.Context) { done <- SpanFromContext(ctx) })
+	got := <-done
+
+	assert.Same(t, tracer.span, got)
+	require.NotNil(t, tracer.config)
+	assert.True(t, tracer.config.NewRoot)
+	assert.Equal(t, []Link{{SpanContext: localSpan.SpanContext()}}, tracer.config.Links)
+
+	select {
+	case <-tracer.span.ended:
+	case <-time.After(time.Second):
+		t.Fatal("linked span not ended")
+	}
+}
+
+func TestGoWithLinkedSpanKeepsOptions(t *testing.T) {
+	tracer := &linkTracer{}
+	ctx := ContextWithSpan(context.Background(), localSpan)
+	kind := WithSpanKind(SpanKindProducer)
+	opts := make([]SpanOption, 1, 3)
+	opts[0] = kind
+
+	done := make(chan struct{})
+	GoWithLinkedSpan(ctx, tracer, "fan-out", func(context.Context) { close(done) }, opts...)
+	<-done
+
+	assert.Equal(t, SpanKindProducer, tracer.config.SpanKind)
+	assert.Equal(t, []SpanOption{kind, nil, nil}, opts[:3], "options of the caller overwritten")
+}
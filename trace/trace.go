@@ -30,6 +30,13 @@ const (
 	// FlagsSampled is a bitmask with the sampled bit set. A SpanContext
 	// with the sampling bit set means the span is sampled.
 	FlagsSampled = TraceFlags(0x01)
+	// FlagsDebug is a bitmask with the debug bit set. A SpanContext with
+	// the debug bit set belongs to a trace requested to be debugged, e.g.
+	// by a Jaeger debug request, that is always sampled. The W3C trace
+	// context format has no such bit: it is only propagated by the formats
+	// supporting it. It is part of the hex representation of the
+	// TraceFlags, e.g. "05" for sampled and debug flags.
+	FlagsDebug = TraceFlags(0x04)
 
 	errInvalidHexID errorConst = "trace-id and span-id can only contain [0-9a-f] characters, all lowercase"
 
@@ -45,11 +52,16 @@ const (
 	traceStateValueFormat                    = `[\x20-\x2b\x2d-\x3c\x3e-\x7e]{0,255}[\x21-\x2b\x2d-\x3c\x3e-\x7e]`
 
 	traceStateMaxListMembers = 32
+	// traceStateMaxLen is the maximum length of the string representation
+	// of a TraceState.
+	traceStateMaxLen = 512
+	// traceStateMaxMemberLen is the length above which list members are
+	// removed first when a TraceState exceeds traceStateMaxLen.
+	traceStateMaxMemberLen = 128
 
 	errInvalidTraceStateKeyValue errorConst = "provided key or value is not valid according to the" +
 		" W3C Trace Context specification"
-	errInvalidTraceStateMembersNumber errorConst = "trace state would exceed the maximum limit of members (32)"
-	errInvalidTraceStateDuplicate     errorConst = "trace state key/value pairs with duplicate keys provided"
+	errInvalidTraceStateDuplicate errorConst = "trace state key/value pairs with duplicate keys provided"
 )
 
 type errorConst string
@@ -64,6 +76,7 @@ type TraceID [16]byte
 
 var nilTraceID TraceID
 var _ json.Marshaler = nilTraceID
+var _ json.Unmarshaler = &nilTraceID
 
 // IsValid checks whether the trace TraceID is valid. A valid trace ID does
 // not consist of zeros only.
@@ -77,6 +90,20 @@ func (t TraceID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.String())
 }
 
+// UnmarshalJSON implements a custom unmarshal function to decode TraceID
+// from a lowercase hex string. An all zero TraceID is decoded without error
+// so the output of MarshalJSON can always be decoded.
+func (t *TraceID) UnmarshalJSON(data []byte) error {
+	var h string
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+	if len(h) != 32 {
+		return errInvalidTraceIDLength
+	}
+	return decodeHex(h, t[:])
+}
+
 // String returns the hex string representation form of a TraceID
 func (t TraceID) String() string {
 	return hex.EncodeToString(t[:])
@@ -87,6 +114,7 @@ type SpanID [8]byte
 
 var nilSpanID SpanID
 var _ json.Marshaler = nilSpanID
+var _ json.Unmarshaler = &nilSpanID
 
 // IsValid checks whether the SpanID is valid. A valid SpanID does not consist
 // of zeros only.
@@ -100,6 +128,20 @@ func (s SpanID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
 
+// UnmarshalJSON implements a custom unmarshal function to decode SpanID
+// from a lowercase hex string. An all zero SpanID is decoded without error
+// so the output of MarshalJSON can always be decoded.
+func (s *SpanID) UnmarshalJSON(data []byte) error {
+	var h string
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+	if len(h) != 16 {
+		return errInvalidSpanIDLength
+	}
+	return decodeHex(h, s[:])
+}
+
 // String returns the hex string representation form of a SpanID
 func (s SpanID) String() string {
 	return hex.EncodeToString(s[:])
@@ -167,7 +209,10 @@ func decodeHex(h string, b []byte) error {
 
 // TraceState provides additional vendor-specific trace identification information
 // across different distributed tracing systems. It represents an immutable list consisting
-// of key/value pairs. There can be a maximum of 32 entries in the list.
+// of key/value pairs. There can be a maximum of 32 entries in the list and its string
+// representation can be at most 512 characters long. Entries exceeding these limits are
+// removed from the end of the list, see
+// https://www.w3.org/TR/trace-context-1/#tracestate-limits.
 //
 // Key and value of each list member must be valid according to the W3C Trace Context specification
 // (see https://www.w3.org/TR/trace-context-1/#key and https://www.w3.org/TR/trace-context-1/#value
@@ -231,21 +276,19 @@ func (ts TraceState) Get(key attribute.Key) attribute.Value {
 // Insert adds a new key/value, if one doesn't exists; otherwise updates the existing entry.
 // The new or updated entry is always inserted at the beginning of the TraceState, i.e.
 // on the left side, as per the W3C Trace Context specification requirement.
+// If the TraceState exceeds its limits with the new entry, the entries at the end of the
+// TraceState, i.e. the oldest ones, are removed.
 func (ts TraceState) Insert(entry attribute.KeyValue) (TraceState, error) {
 	if !isTraceStateKeyValueValid(entry) {
 		return ts, errInvalidTraceStateKeyValue
 	}
 
 	ckvs := ts.copyKVsAndDeleteEntry(entry.Key)
-	if len(ckvs)+1 > traceStateMaxListMembers {
-		return ts, errInvalidTraceStateMembersNumber
-	}
-
 	ckvs = append(ckvs, attribute.KeyValue{})
 	copy(ckvs[1:], ckvs)
 	ckvs[0] = entry
 
-	return TraceState{ckvs}, nil
+	return TraceState{trimTraceState(ckvs)}, nil
 }
 
 // Delete removes specified entry from the trace state.
@@ -276,16 +319,13 @@ func (ts TraceState) copyKVsAndDeleteEntry(key attribute.Key) []attribute.KeyVal
 }
 
 // TraceStateFromKeyValues is a convenience method to create a new TraceState from
-// provided key/value pairs.
+// provided key/value pairs. The pairs exceeding the limits of a TraceState are removed
+// from the end of kvs.
 func TraceStateFromKeyValues(kvs ...attribute.KeyValue) (TraceState, error) { //nolint:golint
 	if len(kvs) == 0 {
 		return TraceState{}, nil
 	}
 
-	if len(kvs) > traceStateMaxListMembers {
-		return TraceState{}, errInvalidTraceStateMembersNumber
-	}
-
 	km := make(map[attribute.Key]bool)
 	for _, kv := range kvs {
 		if !isTraceStateKeyValueValid(kv) {
@@ -300,7 +340,39 @@ func TraceStateFromKeyValues(kvs ...attribute.KeyValue) (TraceState, error) { //
 
 	ckvs := make([]attribute.KeyValue, len(kvs))
 	copy(ckvs, kvs)
-	return TraceState{ckvs}, nil
+	return TraceState{trimTraceState(ckvs)}, nil
+}
+
+// trimTraceState removes entries from the end of kvs until it is within the
+// limits of a TraceState. To reduce the length, entries longer than
+// traceStateMaxMemberLen are removed first. The first entry is never removed.
+// The passed kvs are modified in place.
+func trimTraceState(kvs []attribute.KeyValue) []attribute.KeyValue {
+	if len(kvs) > traceStateMaxListMembers {
+		kvs = kvs[:traceStateMaxListMembers]
+	}
+
+	size := len(kvs) - 1 // Separating commas.
+	for _, kv := range kvs {
+		size += traceStateMemberLen(kv)
+	}
+	for i := len(kvs) - 1; i > 0 && size > traceStateMaxLen; i-- {
+		if n := traceStateMemberLen(kvs[i]); n > traceStateMaxMemberLen {
+			kvs = append(kvs[:i], kvs[i+1:]...)
+			size -= n + 1
+		}
+	}
+	for len(kvs) > 1 && size > traceStateMaxLen {
+		size -= traceStateMemberLen(kvs[len(kvs)-1]) + 1
+		kvs = kvs[:len(kvs)-1]
+	}
+	return kvs
+}
+
+// traceStateMemberLen returns the length of the key=value representation of
+// kv.
+func traceStateMemberLen(kv attribute.KeyValue) int {
+	return len(kv.Key) + 1 + len(kv.Value.Emit())
 }
 
 func isTraceStateKeyValid(key attribute.Key) bool {
@@ -329,6 +401,20 @@ func (tf TraceFlags) WithSampled(sampled bool) TraceFlags {
 	return tf &^ FlagsSampled
 }
 
+// IsDebug returns if the debug bit is set in the TraceFlags.
+func (tf TraceFlags) IsDebug() bool {
+	return tf&FlagsDebug == FlagsDebug
+}
+
+// WithDebug sets the debug bit in a new copy of the TraceFlags.
+func (tf TraceFlags) WithDebug(debug bool) TraceFlags {
+	if debug {
+		return tf | FlagsDebug
+	}
+
+	return tf &^ FlagsDebug
+}
+
 // MarshalJSON implements a custom marshal function to encode TraceFlags
 // as a hex string.
 func (tf TraceFlags) MarshalJSON() ([]byte, error) {
@@ -445,6 +531,11 @@ func (sc SpanContext) IsSampled() bool {
 	return sc.traceFlags.IsSampled()
 }
 
+// IsDebug returns if the debug bit is set in the SpanContext's TraceFlags.
+func (sc SpanContext) IsDebug() bool {
+	return sc.traceFlags.IsDebug()
+}
+
 // WithTraceFlags returns a new SpanContext with the TraceFlags replaced.
 func (sc SpanContext) WithTraceFlags(flags TraceFlags) SpanContext {
 	return SpanContext{
@@ -15,7 +15,9 @@
 package trace
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -236,6 +238,24 @@ func TestTraceFlagsWithSampled(t *testing.T) {
 	}
 }
 
+func TestTraceFlagsDebug(t *testing.T) {
+	assert.False(t, TraceFlags(0).IsDebug())
+	assert.False(t, FlagsSampled.IsDebug())
+	assert.True(t, FlagsDebug.IsDebug())
+	assert.True(t, (FlagsSampled | FlagsDebug).IsDebug())
+
+	tf := FlagsSampled.WithDebug(true)
+	assert.Equal(t, FlagsSampled|FlagsDebug, tf)
+	assert.True(t, tf.IsSampled())
+	assert.Equal(t, "05", tf.String())
+	assert.Equal(t, FlagsSampled, tf.WithDebug(false))
+	assert.Equal(t, FlagsSampled, FlagsSampled.WithDebug(false))
+
+	sc := NewSpanContext(SpanContextConfig{TraceFlags: FlagsDebug})
+	assert.True(t, sc.IsDebug())
+	assert.False(t, sc.IsSampled())
+}
+
 func TestStringTraceID(t *testing.T) {
 	for _, testcase := range []struct {
 		name string
@@ -290,6 +310,68 @@ func TestStringSpanID(t *testing.T) {
 	}
 }
 
+func TestTraceIDJSON(t *testing.T) {
+	tid := TraceID{0x01, 0x02, 0xab}
+	b, err := json.Marshal(tid)
+	require.NoError(t, err)
+	assert.Equal(t, `"0102ab00000000000000000000000000"`, string(b))
+
+	var got TraceID
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, tid, got)
+
+	require.NoError(t, json.Unmarshal([]byte(`"00000000000000000000000000000000"`), &got))
+	assert.Equal(t, TraceID{}, got)
+
+	for _, invalid := range []string{
+		`"0102ab"`,
+		`"0102ab0000000000000000000000000000"`,
+		`"0102AB00000000000000000000000000"`,
+		`"0102xx00000000000000000000000000"`,
+		`[1,2]`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &got), invalid)
+	}
+}
+
+func TestSpanIDJSON(t *testing.T) {
+	sid := SpanID{0x01, 0x02, 0xab}
+	b, err := json.Marshal(sid)
+	require.NoError(t, err)
+	assert.Equal(t, `"0102ab0000000000"`, string(b))
+
+	var got SpanID
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, sid, got)
+
+	require.NoError(t, json.Unmarshal([]byte(`"0000000000000000"`), &got))
+	assert.Equal(t, SpanID{}, got)
+
+	for _, invalid := range []string{
+		`"0102ab"`,
+		`"0102ab000000000000"`,
+		`"0102AB0000000000"`,
+		`"0102xx0000000000"`,
+		`[1,2]`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &got), invalid)
+	}
+}
+
+func TestIDsJSONRoundTrip(t *testing.T) {
+	type ids struct {
+		TraceID TraceID
+		SpanID  SpanID
+	}
+	want := ids{TraceID: TraceID{0x01}, SpanID: SpanID{0x02}}
+	b, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	var got ids
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
+
 func TestValidateSpanKind(t *testing.T) {
 	tests := []struct {
 		in   SpanKind
@@ -576,11 +658,12 @@ func TestTraceStateInsert(t *testing.T) {
 			expectedErr: errInvalidTraceStateKeyValue,
 		},
 		{
-			name:               "Too many entries",
-			traceState:         TraceState{kvsWithMaxMembers},
-			keyValue:           attribute.String("keyx", "valx"),
-			expectedTraceState: TraceState{kvsWithMaxMembers},
-			expectedErr:        errInvalidTraceStateMembersNumber,
+			name:       "Too many entries",
+			traceState: TraceState{kvsWithMaxMembers},
+			keyValue:   attribute.String("keyx", "valx"),
+			expectedTraceState: TraceState{
+				append([]attribute.KeyValue{attribute.String("keyx", "valx")}, kvsWithMaxMembers[:traceStateMaxListMembers-1]...),
+			},
 		},
 	}
 
@@ -599,6 +682,58 @@ func TestTraceStateInsert(t *testing.T) {
 	}
 }
 
+func TestTraceStateLength(t *testing.T) {
+	// member returns an entry with a key=value representation of n characters.
+	member := func(i, n int) attribute.KeyValue {
+		key := fmt.Sprintf("key%02d", i)
+		return attribute.String(key, strings.Repeat("v", n-len(key)-1))
+	}
+
+	testCases := []struct {
+		name string
+		kvs  []attribute.KeyValue
+		want []attribute.KeyValue
+	}{
+		{
+			name: "at limit",
+			// 4*127 + 3 separators = 511, 1 character left.
+			kvs:  []attribute.KeyValue{member(1, 127), member(2, 127), member(3, 127), member(4, 128)},
+			want: []attribute.KeyValue{member(1, 127), member(2, 127), member(3, 127), member(4, 128)},
+		},
+		{
+			name: "over limit",
+			kvs:  []attribute.KeyValue{member(1, 127), member(2, 127), member(3, 127), member(4, 127), member(5, 10)},
+			want: []attribute.KeyValue{member(1, 127), member(2, 127), member(3, 127), member(4, 127)},
+		},
+		{
+			name: "long members first",
+			kvs:  []attribute.KeyValue{member(1, 100), member(2, 200), member(3, 100), member(4, 100), member(5, 100)},
+			want: []attribute.KeyValue{member(1, 100), member(3, 100), member(4, 100), member(5, 100)},
+		},
+		{
+			name: "first member kept",
+			kvs:  []attribute.KeyValue{attribute.String(strings.Repeat("k", 256), strings.Repeat("v", 256)), member(2, 10)},
+			want: []attribute.KeyValue{attribute.String(strings.Repeat("k", 256), strings.Repeat("v", 256))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, err := TraceStateFromKeyValues(tc.kvs...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ts.kvs)
+
+			// Inserting the entries from the oldest to the newest gives the same result.
+			ts = TraceState{}
+			for i := len(tc.kvs) - 1; i >= 0; i-- {
+				ts, err = ts.Insert(tc.kvs[i])
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, ts.kvs)
+		})
+	}
+}
+
 func TestTraceStateFromKeyValues(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -622,8 +757,7 @@ func TestTraceStateFromKeyValues(t *testing.T) {
 				kvs = append(kvs, attribute.String("keyx", "valX"))
 				return kvs
 			}(),
-			expectedTraceState: TraceState{},
-			expectedErr:        errInvalidTraceStateMembersNumber,
+			expectedTraceState: TraceState{kvsWithMaxMembers},
 		},
 		{
 			name: "Duplicate key",
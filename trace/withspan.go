@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/trace"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// WithSpan starts a Span named name with tracer, calls fn with a context
+// containing that Span, and ends the Span once fn returns.
+//
+// If fn returns an error, it is recorded on the Span, the Span status is set
+// to Error, and the error is returned. If fn panics, the Span status is set to
+// Error and the Span is ended before the panic is propagated.
+func WithSpan(ctx context.Context, tracer Tracer, name string, fn func(context.Context) error, opts ...SpanOption) error {
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			span.SetStatus(codes.Error, fmt.Sprint(r))
+			span.End()
+			panic(r)
+		}
+	}()
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+type statusSpan struct {
+	noopSpan
+
+	errs  []error
+	code  codes.Code
+	msg   string
+	ended bool
+}
+
+func (s *statusSpan) RecordError(err error, _ ...EventOption) { s.errs = append(s.errs, err) }
+
+func (s *statusSpan) SetStatus(code codes.Code, msg string) { s.code, s.msg = code, msg }
+
+func (s *statusSpan) End(...SpanOption) { s.ended = true }
+
+type statusTracer struct {
+	span *statusSpan
+}
+
+func (t *statusTracer) Start(ctx context.Context, _ string, _ ...SpanOption) (context.Context, Span) {
+	t.span = &statusSpan{}
+	return ContextWithSpan(ctx, t.span), t.span
+}
+
+func TestWithSpan(t *testing.T) {
+	tracer := &statusTracer{}
+	var got Span
+	err := WithSpan(context.Background(), tracer, "name", func(ctx context.Context) error {
+		got = SpanFromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Same(t, tracer.span, got)
+	assert.True(t, tracer.span.ended)
+	assert.Equal(t, codes.Unset, tracer.span.code)
+	assert.Empty(t, tracer.span.errs)
+}
+
+func TestWithSpanError(t *testing.T) {
+	tracer := &statusTracer{}
+	want := errors.New("failed")
+	err := WithSpan(context.Background(), tracer, "name", func(context.Context) error {
+		return want
+	})
+	assert.Equal(t, want, err)
+	assert.True(t, tracer.span.ended)
+	assert.Equal(t, codes.Error, tracer.span.code)
+	assert.Equal(t, "failed", tracer.span.msg)
+	assert.Equal(t, []error{want}, tracer.span.errs)
+}
+
+func TestWithSpanPanic(t *testing.T) {
+	tracer := &statusTracer{}
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = WithSpan(context.Background(), tracer, "name", func(context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.True(t, tracer.span.ended)
+	assert.Equal(t, codes.Error, tracer.span.code)
+	assert.Equal(t, "boom", tracer.span.msg)
+}